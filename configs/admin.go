@@ -0,0 +1,8 @@
+package configs
+
+import "os"
+
+//get token of the admin endpoints, admin endpoints refuse every request when it isn't set
+func AdminToken() string {
+	return os.Getenv("ADMIN_TOKEN")
+}
@@ -6,13 +6,23 @@ import (
 	"os"
 )
 
+//supported database drivers
+const (
+	DriverMySQL  = "mysql"
+	DriverSQLite = "sqlite"
+	DriverMemory = "memory"
+)
+
 // DBConfig represents db configuration
 type DBConfig struct {
+	Driver   string
 	Host     string
 	Port     string
 	User     string
 	DBName   string
 	Password string
+	Path     string
+	Seed     string
 }
 
 //get env variable
@@ -24,22 +34,40 @@ func mustGetenv(k string) string {
 	return v
 }
 
-//set config database
+//get env variable or the default value
+func getenv(k string, defaultValue string) string {
+	if v := os.Getenv(k); v != "" {
+		return v
+	}
+	return defaultValue
+}
+
+//set config database, mysql settings are only required when mysql driver is used
 func BuildDBConfig() *DBConfig {
 	dbConfig := DBConfig{
-		Host:     mustGetenv("DB_HOST"),
-		Port:     mustGetenv("DB_PORT"),
-		User:     mustGetenv("DB_USER"),
-		Password: mustGetenv("DB_PASSWORD"),
-		DBName:   mustGetenv("DB_NAME"),
+		Driver: getenv("DB_DRIVER", DriverMySQL),
+		Seed:   os.Getenv("DB_SEED"),
+	}
+	switch dbConfig.Driver {
+	case DriverMySQL:
+		dbConfig.Host = mustGetenv("DB_HOST")
+		dbConfig.Port = mustGetenv("DB_PORT")
+		dbConfig.User = mustGetenv("DB_USER")
+		dbConfig.Password = mustGetenv("DB_PASSWORD")
+		dbConfig.DBName = mustGetenv("DB_NAME")
+	case DriverSQLite:
+		dbConfig.Path = getenv("DB_PATH", "hotel-management.db")
+	case DriverMemory:
+	default:
+		log.Fatalf("Warning: unsupported DB_DRIVER %q, use %s, %s or %s.\n", dbConfig.Driver, DriverMySQL, DriverSQLite, DriverMemory)
 	}
 	return &dbConfig
 }
 
-//call database
+//call database, rows affected by an update count the matched rows so conditional updates that keep a value still succeed
 func DbURL(dbConfig *DBConfig) string {
 	return fmt.Sprintf(
-		"%s:%s@tcp(%s:%s)/%s?charset=utf8&parseTime=True&loc=Local",
+		"%s:%s@tcp(%s:%s)/%s?charset=utf8&parseTime=True&loc=UTC&clientFoundRows=true",
 		dbConfig.User,
 		dbConfig.Password,
 		dbConfig.Host,
@@ -47,3 +75,8 @@ func DbURL(dbConfig *DBConfig) string {
 		dbConfig.DBName,
 	)
 }
+
+//call sqlite database with foreign keys enforced
+func SQLiteURL(dbConfig *DBConfig) string {
+	return fmt.Sprintf("file:%s?_foreign_keys=1&_busy_timeout=5000", dbConfig.Path)
+}
@@ -0,0 +1,383 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+// GetAllHotels godoc
+// @Summary Get all hotels
+// @Tags Admin
+// @Description Get every hotel including deactivated hotels
+// @ID get-all-hotels
+// @Produce  json
+// @Param Authorization header string true "Bearer admin token"
+// @Success 200 {array} models.Hotel
+// @Failure 401 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /admin/hotels [get]
+func (c *hotelMgmtController) GetAllHotels(ctx *gin.Context) {
+	//call function to get every hotel
+	res, err := c.service.FindAllHotels()
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CreateHotel godoc
+// @Summary Create hotel
+// @Tags Admin
+// @Description Create hotel, hotels without timezone run on UTC
+// @ID create-hotel
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer admin token"
+// @Param body body models.HotelRequest true "Models of HotelRequest type"
+// @Success 201 {object} models.Hotel
+// @Failure 401 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /admin/hotels [post]
+func (c *hotelMgmtController) CreateHotel(ctx *gin.Context) {
+	var req models.HotelRequest
+
+	err := ctx.BindJSON(&req)
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to create hotel
+	res, err := c.service.CreateHotel(&req)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// UpdateHotel godoc
+// @Summary Update hotel
+// @Tags Admin
+// @Description Change name, address, timezone and stay rules of a hotel
+// @ID update-hotel
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path int true "Hotel ID"
+// @Param body body models.HotelRequest true "Models of HotelRequest type"
+// @Success 200 {object} models.Hotel
+// @Failure 401 {object} models.ErrResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /admin/hotels/{id} [put]
+func (c *hotelMgmtController) UpdateHotel(ctx *gin.Context) {
+	var req models.HotelRequest
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err == nil {
+		err = ctx.BindJSON(&req)
+	}
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to update hotel
+	res, err := c.service.UpdateHotel(id, &req)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DeactivateHotel godoc
+// @Summary Deactivate hotel
+// @Tags Admin
+// @Description Take a hotel off the hotel list, its rooms must be deactivated first
+// @ID deactivate-hotel
+// @Produce  json
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path int true "Hotel ID"
+// @Success 200 {object} models.Hotel
+// @Failure 401 {object} models.ErrResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 409 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /admin/hotels/{id}/deactivate [post]
+func (c *hotelMgmtController) DeactivateHotel(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to deactivate hotel
+	res, err := c.service.DeactivateHotel(id)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetAllRooms godoc
+// @Summary Get all rooms of a hotel
+// @Tags Admin
+// @Description Get every room of a hotel including deactivated rooms
+// @ID get-all-rooms
+// @Produce  json
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path int true "Hotel ID"
+// @Success 200 {array} models.RoomResponse
+// @Failure 401 {object} models.ErrResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /admin/hotels/{id}/rooms [get]
+func (c *hotelMgmtController) GetAllRooms(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to get every room of a hotel
+	res, err := c.service.FindAllRooms(id)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetRoomTypes godoc
+// @Summary Get room types
+// @Tags Admin
+// @Description Get every room type including deactivated room types
+// @ID get-room-types
+// @Produce  json
+// @Param Authorization header string true "Bearer admin token"
+// @Success 200 {array} models.RoomType
+// @Failure 401 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /admin/room-types [get]
+func (c *hotelMgmtController) GetRoomTypes(ctx *gin.Context) {
+	//call function to get every room type
+	res, err := c.service.FindRoomTypes()
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CreateRoomType godoc
+// @Summary Create room type
+// @Tags Admin
+// @Description Create room type
+// @ID create-room-type
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer admin token"
+// @Param body body models.RoomTypeRequest true "Models of RoomTypeRequest type"
+// @Success 201 {object} models.RoomType
+// @Failure 401 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /admin/room-types [post]
+func (c *hotelMgmtController) CreateRoomType(ctx *gin.Context) {
+	var req models.RoomTypeRequest
+
+	err := ctx.BindJSON(&req)
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to create room type
+	res, err := c.service.CreateRoomType(&req)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// UpdateRoomType godoc
+// @Summary Update room type
+// @Tags Admin
+// @Description Change name and base rate of a room type, nights without price are sold at the base rate unless it is 0
+// @ID update-room-type
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path int true "Room Type ID"
+// @Param body body models.RoomTypeRequest true "Models of RoomTypeRequest type"
+// @Success 200 {object} models.RoomType
+// @Failure 401 {object} models.ErrResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /admin/room-types/{id} [put]
+func (c *hotelMgmtController) UpdateRoomType(ctx *gin.Context) {
+	var req models.RoomTypeRequest
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err == nil {
+		err = ctx.BindJSON(&req)
+	}
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to update room type
+	res, err := c.service.UpdateRoomType(id, &req)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DeactivateRoomType godoc
+// @Summary Deactivate room type
+// @Tags Admin
+// @Description Stop using a room type for new rooms, its rooms must be deactivated first
+// @ID deactivate-room-type
+// @Produce  json
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path int true "Room Type ID"
+// @Success 200 {object} models.RoomType
+// @Failure 401 {object} models.ErrResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 409 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /admin/room-types/{id}/deactivate [post]
+func (c *hotelMgmtController) DeactivateRoomType(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to deactivate room type
+	res, err := c.service.DeactivateRoomType(id)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CreateRoom godoc
+// @Summary Create room
+// @Tags Admin
+// @Description Create room in an active hotel with an active room type, room numbers are unique in a hotel
+// @ID create-room
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer admin token"
+// @Param body body models.RoomRequest true "Models of RoomRequest type"
+// @Success 201 {object} models.RoomResponse
+// @Failure 401 {object} models.ErrResponse
+// @Failure 409 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /admin/rooms [post]
+func (c *hotelMgmtController) CreateRoom(ctx *gin.Context) {
+	var req models.RoomRequest
+
+	err := ctx.BindJSON(&req)
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to create room
+	res, err := c.service.CreateRoom(&req)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// UpdateRoom godoc
+// @Summary Update room
+// @Tags Admin
+// @Description Change hotel, room type or room number of a room, rooms with future stays keep their hotel and room type
+// @ID update-room
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path int true "Room ID"
+// @Param body body models.RoomRequest true "Models of RoomRequest type"
+// @Success 200 {object} models.RoomResponse
+// @Failure 401 {object} models.ErrResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 409 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /admin/rooms/{id} [put]
+func (c *hotelMgmtController) UpdateRoom(ctx *gin.Context) {
+	var req models.RoomRequest
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err == nil {
+		err = ctx.BindJSON(&req)
+	}
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to update room
+	res, err := c.service.UpdateRoom(id, &req)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DeactivateRoom godoc
+// @Summary Deactivate room
+// @Tags Admin
+// @Description Take a room out of inventory for good, rooms with future stays can't be deactivated
+// @ID deactivate-room
+// @Produce  json
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path int true "Room ID"
+// @Success 200 {object} models.RoomResponse
+// @Failure 401 {object} models.ErrResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 409 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /admin/rooms/{id}/deactivate [post]
+func (c *hotelMgmtController) DeactivateRoom(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to deactivate room
+	res, err := c.service.DeactivateRoom(id)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
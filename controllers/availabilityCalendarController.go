@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nurcholisnanda/hotel-management-system/services"
+)
+
+// GetAvailabilityCalendar godoc
+// @Summary Get availability calendar
+// @Tags Hotel Management
+// @Description Get total, sold, blocked and available rooms of a room type with the nightly price of every date from the from date until the night before the to date
+// @ID get-availability-calendar
+// @Produce  json
+// @Param id path int true "Hotel ID"
+// @Param room_type_id query int true "Room Type ID" default(1)
+// @Param from query string true "From date" example("2022-12-01")
+// @Param to query string true "To date, the night before it is the last one listed" example("2023-01-01")
+// @Success 200 {object} models.AvailabilityCalendarResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /hotels/{id}/availability-calendar [get]
+func (c *hotelMgmtController) GetAvailabilityCalendar(ctx *gin.Context) {
+	hotelID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+	invalid := &services.ValidationError{}
+	roomTypeID := queryInt(invalid, ctx.Query("room_type_id"), "room_type_id")
+	if err := invalid.Err(); err != nil {
+		serviceError(ctx, err)
+		return
+	}
+
+	//call function to count rooms of every date
+	res, err := c.service.FindAvailabilityCalendar(hotelID, roomTypeID, ctx.Query("from"), ctx.Query("to"))
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
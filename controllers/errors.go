@@ -0,0 +1,131 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nurcholisnanda/hotel-management-system/models"
+	"github.com/nurcholisnanda/hotel-management-system/services"
+)
+
+//status and error code of every service error, the first match wins
+var serviceErrors = []struct {
+	err       error
+	status    int
+	code      string
+	retryable bool
+}{
+	{services.ErrPromoNotFound, http.StatusNotFound, models.ErrorCodePromoNotFound, false},
+	{services.ErrNotFound, http.StatusNotFound, models.ErrorCodeNotFound, false},
+	{services.ErrInvalidRoom, http.StatusBadRequest, models.ErrorCodeBadRequest, false},
+	{services.ErrInvalidTimezone, http.StatusBadRequest, models.ErrorCodeBadRequest, false},
+	{services.ErrInvalidPrice, http.StatusBadRequest, models.ErrorCodeBadRequest, false},
+	{services.ErrNoAvailability, http.StatusConflict, models.ErrorCodeNoAvailability, false},
+	{services.ErrRoomUnavailable, http.StatusConflict, models.ErrorCodeRoomUnavailable, true},
+	{services.ErrOrderStatusChanged, http.StatusConflict, models.ErrorCodeConflict, true},
+	{services.ErrInvalidOrderTransition, http.StatusConflict, models.ErrorCodeConflict, false},
+	{services.ErrReservationCancelled, http.StatusConflict, models.ErrorCodeConflict, false},
+	{services.ErrOutsideStay, http.StatusConflict, models.ErrorCodeConflict, false},
+	{services.ErrInvalidRoomStatus, http.StatusConflict, models.ErrorCodeConflict, false},
+	{services.ErrRoomBooked, http.StatusConflict, models.ErrorCodeConflict, false},
+	{services.ErrRoomBlockOverlap, http.StatusConflict, models.ErrorCodeConflict, false},
+	{services.ErrRoomNumberTaken, http.StatusConflict, models.ErrorCodeConflict, false},
+	{services.ErrHasActiveRooms, http.StatusConflict, models.ErrorCodeConflict, false},
+	{services.ErrPromoIneligible, http.StatusUnprocessableEntity, models.ErrorCodePromoIneligible, false},
+	{services.ErrPromoFullyRedeemed, http.StatusUnprocessableEntity, models.ErrorCodePromoFullyRedeemed, false},
+	{services.ErrPromoCustomerLimit, http.StatusUnprocessableEntity, models.ErrorCodePromoCustomerLimit, false},
+}
+
+//respond a service error with its status and error code, unknown errors are logged and hidden behind a 500
+func serviceError(ctx *gin.Context, err error) {
+	if validationFailed(ctx, err) || rateNotLoaded(ctx, err) || roomBlockConflict(ctx, err) {
+		return
+	}
+	for _, known := range serviceErrors {
+		if !errors.Is(err, known.err) {
+			continue
+		}
+		res := models.ErrResponse{
+			Status:    known.status,
+			Message:   err.Error(),
+			Success:   false,
+			Retryable: known.retryable,
+			ErrorCode: known.code,
+		}
+		var ineligible *services.PromoIneligibleError
+		if errors.As(err, &ineligible) {
+			res.Details = map[string]interface{}{"promo_id": ineligible.PromoID, "failed_rules": ineligible.FailedRules}
+		}
+		ctx.JSON(known.status, res)
+		return
+	}
+
+	log.Printf("%s %s: %v", ctx.Request.Method, ctx.Request.URL.Path, err)
+	ctx.JSON(http.StatusInternalServerError, models.ErrResponse{
+		Status:    http.StatusInternalServerError,
+		Message:   "something went wrong on our side, please try again later",
+		Success:   false,
+		Retryable: true,
+		ErrorCode: models.ErrorCodeInternal,
+	})
+}
+
+//respond 400 for a request which can't be read
+func badRequest(ctx *gin.Context, err error) {
+	ctx.JSON(http.StatusBadRequest, models.ErrResponse{
+		Status:    http.StatusBadRequest,
+		Message:   err.Error(),
+		Success:   false,
+		ErrorCode: models.ErrorCodeBadRequest,
+	})
+}
+
+//respond 400 with every invalid field when err is a validation error
+func validationFailed(ctx *gin.Context, err error) bool {
+	var invalid *services.ValidationError
+	if !errors.As(err, &invalid) {
+		return false
+	}
+	ctx.JSON(http.StatusBadRequest, models.ErrResponse{
+		Status:    http.StatusBadRequest,
+		Message:   err.Error(),
+		Success:   false,
+		ErrorCode: models.ErrorCodeValidation,
+		Errors:    invalid.Fields,
+	})
+	return true
+}
+
+//respond rate not loaded error with the nights which have no price, returns false for other errors
+func rateNotLoaded(ctx *gin.Context, err error) bool {
+	var missingRates *services.MissingRatesError
+	if !errors.As(err, &missingRates) {
+		return false
+	}
+	ctx.JSON(http.StatusUnprocessableEntity, models.RateNotLoadedResponse{
+		Status:       http.StatusUnprocessableEntity,
+		Message:      err.Error(),
+		Success:      false,
+		ErrorCode:    models.ErrorCodeRateNotLoaded,
+		MissingDates: missingRates.MissingDates,
+	})
+	return true
+}
+
+//respond 409 with the sold nights a room block would cover, returns false for other errors
+func roomBlockConflict(ctx *gin.Context, err error) bool {
+	var conflict *services.RoomBlockConflictError
+	if !errors.As(err, &conflict) {
+		return false
+	}
+	ctx.JSON(http.StatusConflict, models.RoomBlockConflictResponse{
+		Status:    http.StatusConflict,
+		Message:   err.Error(),
+		Success:   false,
+		ErrorCode: models.ErrorCodeConflict,
+		Conflicts: conflict.Conflicts,
+	})
+	return true
+}
@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nurcholisnanda/hotel-management-system/services"
+)
+
+// GetFlexibleStays godoc
+// @Summary Get flexible date stays
+// @Tags Hotel Management
+// @Description List every stay of the given nights inside the date window from the cheapest, the calendar tells the status of every check-in date
+// @ID get-flexible-stays
+// @Accept  json
+// @Produce  json
+// @Param hotel_id query int true "Hotel ID" default(1)
+// @Param room_type_id query int true "Room Type ID" default(1)
+// @Param from_date query string true "First check-in date of the window" example("2022-12-01")
+// @Param to_date query string true "Last checkout date of the window" example("2023-01-01")
+// @Param nights query int true "Nights" default(3)
+// @Param room_qty query int false "Room Qty" default(1)
+// @Success 200 {object} models.FlexStaysResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /available-rooms/flex [get]
+func (c *hotelMgmtController) GetFlexibleStays(ctx *gin.Context) {
+	invalid := &services.ValidationError{}
+	hotelID := queryInt(invalid, ctx.Query("hotel_id"), "hotel_id")
+	roomTypeID := queryInt(invalid, ctx.Query("room_type_id"), "room_type_id")
+	nights := queryInt(invalid, ctx.Query("nights"), "nights")
+	roomQty := 1
+	if ctx.Query("room_qty") != "" {
+		roomQty = queryInt(invalid, ctx.Query("room_qty"), "room_qty")
+	}
+	if err := invalid.Err(); err != nil {
+		serviceError(ctx, err)
+		return
+	}
+
+	//call function to search every check-in date of the window
+	res, err := c.service.FindFlexibleStays(hotelID, roomTypeID, ctx.Query("from_date"), ctx.Query("to_date"), nights, roomQty)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
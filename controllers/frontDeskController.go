@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+// CheckInReservation godoc
+// @Summary Check in reservation
+// @Tags Front Desk
+// @Description Assign booked rooms to guests and record their arrival, the order must be confirmed
+// @ID check-in-reservation
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Reservation ID"
+// @Param body body models.CheckInRequest true "Models of CheckInRequest type"
+// @Success 200 {object} models.FrontDeskResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 409 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /reservations/{id}/check-in [post]
+func (c *hotelMgmtController) CheckInReservation(ctx *gin.Context) {
+	var req models.CheckInRequest
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err == nil {
+		err = ctx.BindJSON(&req)
+	}
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to check in reservation
+	res, err := c.service.CheckInReservation(id, &req)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CheckOutReservation godoc
+// @Summary Check out reservation
+// @Tags Front Desk
+// @Description Close the stays, flag their rooms dirty and release the remaining nights of an early departure. The whole booked stay stays charged, released nights are not refunded.
+// @ID check-out-reservation
+// @Produce  json
+// @Param id path int true "Reservation ID"
+// @Success 200 {object} models.FrontDeskResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 409 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /reservations/{id}/check-out [post]
+func (c *hotelMgmtController) CheckOutReservation(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to check out reservation
+	res, err := c.service.CheckOutReservation(id)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
@@ -11,8 +11,45 @@ import (
 )
 
 type HotelMgmtController interface {
+	GetHotels(ctx *gin.Context)
 	GetAvailableRooms(ctx *gin.Context)
 	GetPromoPriceRooms(ctx *gin.Context)
+	GetBestPromoRooms(ctx *gin.Context)
+	GetFlexibleStays(ctx *gin.Context)
+	GetMultiRoomAvailability(ctx *gin.Context)
+	GetAvailabilityCalendar(ctx *gin.Context)
+	CreateReservation(ctx *gin.Context)
+	CreateMultiRoomReservation(ctx *gin.Context)
+	UpdateReservation(ctx *gin.Context)
+	CancelReservation(ctx *gin.Context)
+	UpdateRoomStatus(ctx *gin.Context)
+	GetHousekeepingBoard(ctx *gin.Context)
+	GetRoomBlocks(ctx *gin.Context)
+	GetRoomBlockReport(ctx *gin.Context)
+	GetRoomBlock(ctx *gin.Context)
+	CreateRoomBlock(ctx *gin.Context)
+	UpdateRoomBlock(ctx *gin.Context)
+	DeleteRoomBlock(ctx *gin.Context)
+	CheckInReservation(ctx *gin.Context)
+	CheckOutReservation(ctx *gin.Context)
+	ChangeOrderStatus(ctx *gin.Context)
+	GetOrderHistory(ctx *gin.Context)
+	GetAllHotels(ctx *gin.Context)
+	CreateHotel(ctx *gin.Context)
+	UpdateHotel(ctx *gin.Context)
+	DeactivateHotel(ctx *gin.Context)
+	GetRoomTypes(ctx *gin.Context)
+	CreateRoomType(ctx *gin.Context)
+	UpdateRoomType(ctx *gin.Context)
+	DeactivateRoomType(ctx *gin.Context)
+	GetAllRooms(ctx *gin.Context)
+	CreateRoom(ctx *gin.Context)
+	UpdateRoom(ctx *gin.Context)
+	DeactivateRoom(ctx *gin.Context)
+	GetPriceCalendar(ctx *gin.Context)
+	SetPriceRange(ctx *gin.Context)
+	ImportPrices(ctx *gin.Context)
+	ExportPrices(ctx *gin.Context)
 }
 
 type hotelMgmtController struct {
@@ -25,6 +62,24 @@ func NewHotelMgmtController(service services.HotelMgmtService) HotelMgmtControll
 	}
 }
 
+// GetHotels godoc
+// @Summary Get hotels
+// @Tags Hotel Management
+// @Description Get list of hotels
+// @ID get-hotels
+// @Produce  json
+// @Success 200 {array} models.Hotel
+// @Failure 500 {object} models.ErrResponse
+// @Router /hotels [get]
+func (c *hotelMgmtController) GetHotels(ctx *gin.Context) {
+	hotels, err := c.service.FindHotels()
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, hotels)
+}
+
 // GetAvailableRooms godoc
 // @Summary Get available rooms
 // @Tags Hotel Management
@@ -32,78 +87,135 @@ func NewHotelMgmtController(service services.HotelMgmtService) HotelMgmtControll
 // @ID get-available-rooms
 // @Accept  json
 // @Produce  json
+// @Param hotel_id query int true "Hotel ID" default(1)
 // @Param checkin_date query string true "Checkin date" example("2022-12-31")
 // @Param checkout_date query string true "Checkout date" example("2022-12-31")
 // @Param room_qty query int true "Room Qty" default(1)
 // @Param room_type_id query int true "Room Type ID" default(1)
 // @Success 200 {object} models.HotelAvailableRoomsResponse
 // @Failure 404 {object} models.ErrResponse
+// @Failure 409 {object} models.ErrResponse
 // @Failure 400 {object} models.ErrResponse
+// @Failure 422 {object} models.RateNotLoadedResponse
+// @Failure 500 {object} models.ErrResponse
 // @Router /available-rooms [get]
 func (c *hotelMgmtController) GetAvailableRooms(ctx *gin.Context) {
+	query, err := parseAvailableRoomsQuery(ctx)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+
+	//call function to get available rooms
+	availableRooms, err := c.service.FindAvailableRooms(query.hotelID, query.checkinDate, query.checkoutDate, query.roomQty, query.roomTypeID)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	} else {
+		ctx.JSON(http.StatusOK, availableRooms)
+	}
+}
+
+// GetBestPromoRooms godoc
+// @Summary Get best promo for available rooms
+// @Tags Hotel Management
+// @Description Evaluate every promo for available rooms and rank the eligible ones by discount
+// @ID get-best-promo-rooms
+// @Accept  json
+// @Produce  json
+// @Param hotel_id query int true "Hotel ID" default(1)
+// @Param checkin_date query string true "Checkin date" example("2022-12-31")
+// @Param checkout_date query string true "Checkout date" example("2022-12-31")
+// @Param room_qty query int true "Room Qty" default(1)
+// @Param room_type_id query int true "Room Type ID" default(1)
+// @Success 200 {object} models.BestPromoRoomsResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 409 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 422 {object} models.RateNotLoadedResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /best-promo-rooms [get]
+func (c *hotelMgmtController) GetBestPromoRooms(ctx *gin.Context) {
+	query, err := parseAvailableRoomsQuery(ctx)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+
+	//call function to rank promos for available rooms
+	bestPromoRooms, err := c.service.FindBestPromoRooms(query.hotelID, query.checkinDate, query.checkoutDate, query.roomQty, query.roomTypeID)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, bestPromoRooms)
+}
+
+type availableRoomsQuery struct {
+	hotelID      int
+	checkinDate  string
+	checkoutDate string
+	roomQty      int
+	roomTypeID   int
+}
+
+//parse and validate available rooms search query, every invalid field is reported at once
+func parseAvailableRoomsQuery(ctx *gin.Context) (*availableRoomsQuery, error) {
 	queryParam := ctx.Request.URL.Query()
 	dateForm := "2006-01-02"
+	invalid := &services.ValidationError{}
+
+	//hotel id validation
+	hotelID := queryInt(invalid, queryParam.Get("hotel_id"), "hotel_id")
 
 	//date validation
 	checkinDate := queryParam.Get("checkin_date")
-	_, err := time.Parse(dateForm, checkinDate)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrResponse{
-			Status:  http.StatusBadRequest,
-			Message: err.Error(),
-			Success: false,
-		})
-		return
+	if checkinDate == "" {
+		invalid.Add("checkin_date", services.RuleRequired, "checkin_date is required")
+	} else if _, err := time.Parse(dateForm, checkinDate); err != nil {
+		invalid.Add("checkin_date", services.RuleFormat, "checkin_date must look like "+dateForm)
 	}
 
 	//date validation
 	checkoutDate := queryParam.Get("checkout_date")
-	_, err = time.Parse(dateForm, checkoutDate)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrResponse{
-			Status:  http.StatusBadRequest,
-			Message: err.Error(),
-			Success: false,
-		})
-		return
+	if checkoutDate == "" {
+		invalid.Add("checkout_date", services.RuleRequired, "checkout_date is required")
+	} else if _, err := time.Parse(dateForm, checkoutDate); err != nil {
+		invalid.Add("checkout_date", services.RuleFormat, "checkout_date must look like "+dateForm)
 	}
 
 	//room quantity validation
-	roomQty := queryParam.Get("room_qty")
-	qty, err := strconv.ParseUint(roomQty, 10, 32)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrResponse{
-			Status:  http.StatusBadRequest,
-			Message: err.Error(),
-			Success: false,
-		})
-		return
+	qty := queryInt(invalid, queryParam.Get("room_qty"), "room_qty")
+	if queryParam.Get("room_qty") != "" && qty < 1 {
+		invalid.Add("room_qty", services.RuleMin, "room_qty must be at least 1")
 	}
 
 	//room type id validation
-	roomTypeID := queryParam.Get("room_type_id")
-	rTypeId, err := strconv.Atoi(roomTypeID)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrResponse{
-			Status:  http.StatusBadRequest,
-			Message: err.Error(),
-			Success: false,
-		})
-		return
+	roomTypeID := queryInt(invalid, queryParam.Get("room_type_id"), "room_type_id")
+
+	if err := invalid.Err(); err != nil {
+		return nil, err
 	}
+	return &availableRoomsQuery{
+		hotelID:      hotelID,
+		checkinDate:  checkinDate,
+		checkoutDate: checkoutDate,
+		roomQty:      qty,
+		roomTypeID:   roomTypeID,
+	}, nil
+}
 
-	//call function to get available rooms
-	availableRooms, err := c.service.FindAvailableRooms(checkinDate, checkoutDate, int(qty), rTypeId)
+//parse a required number of the query, a missing or malformed value is added to the validation error
+func queryInt(invalid *services.ValidationError, value string, field string) int {
+	if value == "" {
+		invalid.Add(field, services.RuleRequired, field+" is required")
+		return 0
+	}
+	number, err := strconv.Atoi(value)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, models.ErrResponse{
-			Status:  http.StatusNotFound,
-			Message: err.Error(),
-			Success: false,
-		})
-		return
-	} else {
-		ctx.JSON(http.StatusOK, availableRooms)
+		invalid.Add(field, services.RuleFormat, field+" must be a number")
 	}
+	return number
 }
 
 // GetPromoPriceRooms godoc
@@ -116,28 +228,23 @@ func (c *hotelMgmtController) GetAvailableRooms(ctx *gin.Context) {
 // @Param body body models.PromoRoomsRequest true "Models of PromoRoomsRequest type"
 // @Success 200 {object} models.PromoRoomsResponse
 // @Failure 404 {object} models.ErrResponse
+// @Failure 409 {object} models.ErrResponse
 // @Failure 400 {object} models.ErrResponse
+// @Failure 422 {object} models.RateNotLoadedResponse
+// @Failure 500 {object} models.ErrResponse
 // @Router /promo-rooms [post]
 func (c *hotelMgmtController) GetPromoPriceRooms(ctx *gin.Context) {
 	var req models.PromoRoomsRequest
 
 	err := ctx.BindJSON(&req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.ErrResponse{
-			Status:  http.StatusBadRequest,
-			Message: err.Error(),
-			Success: false,
-		})
+		badRequest(ctx, err)
 		return
 	} else {
 		//call function to get promo rooms
 		PromoRooms, err := c.service.FindPromoRooms(&req)
 		if err != nil {
-			ctx.JSON(http.StatusNotFound, models.ErrResponse{
-				Status:  http.StatusNotFound,
-				Message: err.Error(),
-				Success: false,
-			})
+			serviceError(ctx, err)
 			return
 		} else {
 			ctx.JSON(http.StatusOK, PromoRooms)
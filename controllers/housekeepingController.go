@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+// UpdateRoomStatus godoc
+// @Summary Update room status
+// @Tags Housekeeping
+// @Description Set housekeeping status of a room, out-of-order rooms are not sold between from date and until date
+// @ID update-room-status
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Room ID"
+// @Param body body models.RoomStatusRequest true "Models of RoomStatusRequest type"
+// @Success 200 {object} models.Room
+// @Failure 404 {object} models.ErrResponse
+// @Failure 409 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /rooms/{id}/status [put]
+func (c *hotelMgmtController) UpdateRoomStatus(ctx *gin.Context) {
+	var req models.RoomStatusRequest
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err == nil {
+		err = ctx.BindJSON(&req)
+	}
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to update room status
+	room, err := c.service.UpdateRoomStatus(id, &req)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, room)
+}
+
+// GetHousekeepingBoard godoc
+// @Summary Get housekeeping task board
+// @Tags Housekeeping
+// @Description Get occupancy and housekeeping task of every room of a hotel on a day
+// @ID get-housekeeping-board
+// @Produce  json
+// @Param id path int true "Hotel ID"
+// @Param date query string false "Date, today in hotel timezone when empty" example("2022-12-31")
+// @Success 200 {object} models.HousekeepingBoardResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /hotels/{id}/housekeeping [get]
+func (c *hotelMgmtController) GetHousekeepingBoard(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to get housekeeping task board
+	board, err := c.service.FindHousekeepingBoard(id, ctx.Query("date"))
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, board)
+}
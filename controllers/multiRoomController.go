@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+// GetMultiRoomAvailability godoc
+// @Summary Get available rooms of several room types
+// @Tags Hotel Management
+// @Description Search every line of room type and room qty for the same stay, the result is available when every line is available
+// @ID get-multi-room-availability
+// @Accept  json
+// @Produce  json
+// @Param body body models.MultiRoomSearchRequest true "Models of MultiRoomSearchRequest type"
+// @Success 200 {object} models.MultiRoomSearchResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 422 {object} models.RateNotLoadedResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /available-rooms/multi [post]
+func (c *hotelMgmtController) GetMultiRoomAvailability(ctx *gin.Context) {
+	var req models.MultiRoomSearchRequest
+
+	err := ctx.BindJSON(&req)
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to search every line
+	res, err := c.service.FindMultiRoomAvailability(&req)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CreateMultiRoomReservation godoc
+// @Summary Create reservations of several room types
+// @Tags Reservation
+// @Description Book every line of room type and room qty for the same stay, one reservation is created for each line and all of them are booked or none
+// @ID create-multi-room-reservation
+// @Accept  json
+// @Produce  json
+// @Param body body models.MultiRoomReservationRequest true "Models of MultiRoomReservationRequest type"
+// @Success 201 {object} models.MultiRoomReservationResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 409 {object} models.ErrResponse
+// @Failure 422 {object} models.RateNotLoadedResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /reservations/multi [post]
+func (c *hotelMgmtController) CreateMultiRoomReservation(ctx *gin.Context) {
+	var req models.MultiRoomReservationRequest
+
+	err := ctx.BindJSON(&req)
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to book every line
+	res, err := c.service.CreateMultiRoomReservation(&req)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, res)
+}
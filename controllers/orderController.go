@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+// ChangeOrderStatus godoc
+// @Summary Change order status
+// @Tags Order
+// @Description Confirm an order or mark it as no-show, which puts its nights back on sale. Other statuses are set by the reservation endpoints
+// @ID change-order-status
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Order ID"
+// @Param body body models.OrderStatusRequest true "Models of OrderStatusRequest type"
+// @Success 200 {object} models.OrderHistoryResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 409 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /orders/{id}/status [post]
+func (c *hotelMgmtController) ChangeOrderStatus(ctx *gin.Context) {
+	var req models.OrderStatusRequest
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err == nil {
+		err = ctx.BindJSON(&req)
+	}
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to change order status
+	history, err := c.service.ChangeOrderStatus(id, req.Status)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, history)
+}
+
+// GetOrderHistory godoc
+// @Summary Get order history
+// @Tags Order
+// @Description Get every status change of an order, oldest first
+// @ID get-order-history
+// @Produce  json
+// @Param id path int true "Order ID"
+// @Success 200 {object} models.OrderHistoryResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /orders/{id}/history [get]
+func (c *hotelMgmtController) GetOrderHistory(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to get order history
+	history, err := c.service.FindOrderHistory(id)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, history)
+}
@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+// GetPriceCalendar godoc
+// @Summary Get rate calendar
+// @Tags Rate Calendar
+// @Description Get nightly prices of a room type with the nights that have no price, from today for a year when dates are empty
+// @ID get-price-calendar
+// @Produce  json
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path int true "Hotel ID"
+// @Param room_type_id path int true "Room Type ID"
+// @Param from_date query string false "From date" example("2022-12-01")
+// @Param to_date query string false "To date, the night before it is the last one listed" example("2023-01-01")
+// @Success 200 {object} models.PriceCalendarResponse
+// @Failure 401 {object} models.ErrResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /hotels/{id}/room-types/{room_type_id}/prices [get]
+func (c *hotelMgmtController) GetPriceCalendar(ctx *gin.Context) {
+	hotelID, roomTypeID, err := priceIDs(ctx)
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to get rate calendar
+	calendar, err := c.service.FindPriceCalendar(hotelID, roomTypeID, ctx.Query("from_date"), ctx.Query("to_date"))
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, calendar)
+}
+
+// SetPriceRange godoc
+// @Summary Set prices of a date range
+// @Tags Rate Calendar
+// @Description Set one price for every night from the from date until the night before the to date, nights on the given days of week get their own price
+// @ID set-price-range
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path int true "Hotel ID"
+// @Param room_type_id path int true "Room Type ID"
+// @Param body body models.PriceRangeRequest true "Models of PriceRangeRequest type"
+// @Success 200 {object} models.PriceCalendarResponse
+// @Failure 401 {object} models.ErrResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /hotels/{id}/room-types/{room_type_id}/prices [put]
+func (c *hotelMgmtController) SetPriceRange(ctx *gin.Context) {
+	var req models.PriceRangeRequest
+
+	hotelID, roomTypeID, err := priceIDs(ctx)
+	if err == nil {
+		err = ctx.BindJSON(&req)
+	}
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to set prices of a date range
+	calendar, err := c.service.SetPriceRange(hotelID, roomTypeID, &req)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, calendar)
+}
+
+// ImportPrices godoc
+// @Summary Import prices from csv
+// @Tags Rate Calendar
+// @Description Load nightly prices from a csv file with the header date,price, the whole file is rejected when a line is invalid and the errors name every invalid line like lines[3].price
+// @ID import-prices
+// @Accept  text/csv
+// @Produce  json
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path int true "Hotel ID"
+// @Param room_type_id path int true "Room Type ID"
+// @Param body body string true "Csv file with date and price columns"
+// @Success 200 {object} models.PriceCalendarResponse
+// @Failure 401 {object} models.ErrResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /hotels/{id}/room-types/{room_type_id}/prices/import [post]
+func (c *hotelMgmtController) ImportPrices(ctx *gin.Context) {
+	hotelID, roomTypeID, err := priceIDs(ctx)
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to import prices
+	calendar, err := c.service.ImportPrices(hotelID, roomTypeID, ctx.Request.Body)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, calendar)
+}
+
+// ExportPrices godoc
+// @Summary Export prices to csv
+// @Tags Rate Calendar
+// @Description Download nightly prices as a csv file with the header date,price which can be imported again
+// @ID export-prices
+// @Produce  text/csv
+// @Param Authorization header string true "Bearer admin token"
+// @Param id path int true "Hotel ID"
+// @Param room_type_id path int true "Room Type ID"
+// @Param from_date query string false "From date" example("2022-12-01")
+// @Param to_date query string false "To date, the night before it is the last one exported" example("2023-01-01")
+// @Success 200 {string} string
+// @Failure 401 {object} models.ErrResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /hotels/{id}/room-types/{room_type_id}/prices/export [get]
+func (c *hotelMgmtController) ExportPrices(ctx *gin.Context) {
+	hotelID, roomTypeID, err := priceIDs(ctx)
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to export prices
+	var file bytes.Buffer
+	if err = c.service.ExportPrices(hotelID, roomTypeID, ctx.Query("from_date"), ctx.Query("to_date"), &file); err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=prices-%d-%d.csv", hotelID, roomTypeID))
+	ctx.Data(http.StatusOK, "text/csv", file.Bytes())
+}
+
+//parse hotel id and room type id of the rate calendar path
+func priceIDs(ctx *gin.Context) (hotelID int, roomTypeID int, err error) {
+	hotelID, err = strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return 0, 0, err
+	}
+	roomTypeID, err = strconv.Atoi(ctx.Param("room_type_id"))
+	if err != nil {
+		return 0, 0, err
+	}
+	return hotelID, roomTypeID, nil
+}
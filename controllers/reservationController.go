@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+// CreateReservation godoc
+// @Summary Create reservation
+// @Tags Reservation
+// @Description Book rooms from available rooms or promo rooms result
+// @ID create-reservation
+// @Accept  json
+// @Produce  json
+// @Param body body models.ReservationRequest true "Models of ReservationRequest type"
+// @Success 201 {object} models.ReservationResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 409 {object} models.ErrResponse
+// @Failure 422 {object} models.RateNotLoadedResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /reservations [post]
+func (c *hotelMgmtController) CreateReservation(ctx *gin.Context) {
+	var req models.ReservationRequest
+
+	err := ctx.BindJSON(&req)
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to create reservation
+	reservation, err := c.service.CreateReservation(&req)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, reservation)
+}
+
+// UpdateReservation godoc
+// @Summary Update reservation
+// @Tags Reservation
+// @Description Change dates, room qty or room type of a reservation and re-price it, already held room nights are kept
+// @ID update-reservation
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Reservation ID"
+// @Param body body models.ReservationUpdateRequest true "Models of ReservationUpdateRequest type"
+// @Success 200 {object} models.ReservationUpdateResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 409 {object} models.ErrResponse
+// @Failure 422 {object} models.RateNotLoadedResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /reservations/{id} [patch]
+func (c *hotelMgmtController) UpdateReservation(ctx *gin.Context) {
+	var req models.ReservationUpdateRequest
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err == nil {
+		err = ctx.BindJSON(&req)
+	}
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to update reservation
+	reservation, err := c.service.UpdateReservation(id, &req)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, reservation)
+}
+
+// CancelReservation godoc
+// @Summary Cancel reservation
+// @Tags Reservation
+// @Description Cancel reservation, release its rooms and charge the penalty of its cancellation policy
+// @ID cancel-reservation
+// @Produce  json
+// @Param id path int true "Reservation ID"
+// @Success 200 {object} models.CancellationResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 409 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /reservations/{id}/cancel [post]
+func (c *hotelMgmtController) CancelReservation(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to cancel reservation
+	cancellation, err := c.service.CancelReservation(id)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, cancellation)
+}
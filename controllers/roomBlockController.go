@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+// GetRoomBlocks godoc
+// @Summary Get room blocks
+// @Tags Room Block
+// @Description Get room blocks of a hotel overlapping from date until to date
+// @ID get-room-blocks
+// @Produce  json
+// @Param id path int true "Hotel ID"
+// @Param from_date query string false "From date, today in hotel timezone when empty" example("2022-12-31")
+// @Param to_date query string false "To date, open ended when empty" example("2022-12-31")
+// @Success 200 {array} models.RoomBlock
+// @Failure 404 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /hotels/{id}/room-blocks [get]
+func (c *hotelMgmtController) GetRoomBlocks(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to get room blocks
+	blocks, err := c.service.FindRoomBlocks(id, ctx.Query("from_date"), ctx.Query("to_date"))
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, blocks)
+}
+
+// GetRoomBlockReport godoc
+// @Summary Get blocked room nights report
+// @Tags Room Block
+// @Description Get every blocked room night of a hotel between from date and to date
+// @ID get-room-block-report
+// @Produce  json
+// @Param id path int true "Hotel ID"
+// @Param from_date query string false "From date, today in hotel timezone when empty" example("2022-12-31")
+// @Param to_date query string false "To date, open ended when empty" example("2022-12-31")
+// @Success 200 {object} models.RoomBlockReportResponse
+// @Failure 404 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /hotels/{id}/room-blocks/report [get]
+func (c *hotelMgmtController) GetRoomBlockReport(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to get blocked room nights report
+	report, err := c.service.FindRoomBlockReport(id, ctx.Query("from_date"), ctx.Query("to_date"))
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
+
+// GetRoomBlock godoc
+// @Summary Get room block
+// @Tags Room Block
+// @Description Get room block by id
+// @ID get-room-block
+// @Produce  json
+// @Param id path int true "Room Block ID"
+// @Success 200 {object} models.RoomBlock
+// @Failure 404 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /room-blocks/{id} [get]
+func (c *hotelMgmtController) GetRoomBlock(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to get room block
+	block, err := c.service.FindRoomBlock(id)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, block)
+}
+
+// CreateRoomBlock godoc
+// @Summary Create room block
+// @Tags Room Block
+// @Description Block a room from from date until the night before until date, sold room nights are listed as conflicts
+// @ID create-room-block
+// @Accept  json
+// @Produce  json
+// @Param body body models.RoomBlockRequest true "Models of RoomBlockRequest type"
+// @Success 201 {object} models.RoomBlock
+// @Failure 404 {object} models.ErrResponse
+// @Failure 409 {object} models.RoomBlockConflictResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /room-blocks [post]
+func (c *hotelMgmtController) CreateRoomBlock(ctx *gin.Context) {
+	var req models.RoomBlockRequest
+
+	err := ctx.BindJSON(&req)
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to create room block
+	block, err := c.service.CreateRoomBlock(&req)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, block)
+}
+
+// UpdateRoomBlock godoc
+// @Summary Update room block
+// @Tags Room Block
+// @Description Change room, dates or reason of a room block, sold room nights are listed as conflicts
+// @ID update-room-block
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Room Block ID"
+// @Param body body models.RoomBlockRequest true "Models of RoomBlockRequest type"
+// @Success 200 {object} models.RoomBlock
+// @Failure 404 {object} models.ErrResponse
+// @Failure 409 {object} models.RoomBlockConflictResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /room-blocks/{id} [put]
+func (c *hotelMgmtController) UpdateRoomBlock(ctx *gin.Context) {
+	var req models.RoomBlockRequest
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err == nil {
+		err = ctx.BindJSON(&req)
+	}
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to update room block
+	block, err := c.service.UpdateRoomBlock(id, &req)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, block)
+}
+
+// DeleteRoomBlock godoc
+// @Summary Delete room block
+// @Tags Room Block
+// @Description Remove room block, the room can be sold again
+// @ID delete-room-block
+// @Param id path int true "Room Block ID"
+// @Success 204
+// @Failure 404 {object} models.ErrResponse
+// @Failure 400 {object} models.ErrResponse
+// @Failure 500 {object} models.ErrResponse
+// @Router /room-blocks/{id} [delete]
+func (c *hotelMgmtController) DeleteRoomBlock(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		badRequest(ctx, err)
+		return
+	}
+
+	//call function to delete room block
+	if err = c.service.DeleteRoomBlock(id); err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 	"os"
+	_ "time/tzdata" // hotel timezones when the host has no zoneinfo
 
 	"github.com/nurcholisnanda/hotel-management-system/routes"
 )
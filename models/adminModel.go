@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+type HotelRequest struct {
+	HotelName      string `json:"hotel_name" binding:"required"`
+	Address        string `json:"address"`
+	Timezone       string `json:"timezone"`
+	MinStayNights  int    `json:"min_stay_nights"`
+	MaxStayNights  int    `json:"max_stay_nights"`
+	BookingHorizon int    `json:"booking_horizon_days"`
+}
+
+type RoomTypeRequest struct {
+	Name     string `json:"name" binding:"required"`
+	BaseRate int    `json:"base_rate"`
+}
+
+type RoomRequest struct {
+	HotelID    int `json:"hotel_id" binding:"required"`
+	RoomTypeID int `json:"room_type_id" binding:"required"`
+	RoomNumber int `json:"room_number" binding:"required"`
+}
+
+//RoomResponse shows the hotel and room type of a room which are hidden from the booking responses
+type RoomResponse struct {
+	RoomID        int        `json:"room_id"`
+	HotelID       int        `json:"hotel_id"`
+	RoomTypeID    int        `json:"room_type_id"`
+	RoomNumber    int        `json:"room_number"`
+	RoomStatus    string     `json:"room_status"`
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+}
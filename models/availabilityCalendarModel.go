@@ -0,0 +1,40 @@
+package models
+
+//DateCount is the number of rooms counted on one date by an aggregate query
+type DateCount struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+//RoomDateRange is a room taken from sale on every date from the from date until the night before the until date,
+//an empty date leaves the range open
+type RoomDateRange struct {
+	RoomID    int    `json:"room_id"`
+	FromDate  string `json:"from_date"`
+	UntilDate string `json:"until_date"`
+}
+
+//AvailabilityDay tells how many rooms of a room type can still be sold on a date and the price of the night,
+//out-of-order rooms that are also blocked are counted as blocked only,
+//a night without price is sold at the base rate of the room type and has no rate when the base rate is 0
+type AvailabilityDay struct {
+	Date            string `json:"date"`
+	TotalRooms      int    `json:"total_rooms"`
+	SoldRooms       int    `json:"sold_rooms"`
+	BlockedRooms    int    `json:"blocked_rooms"`
+	OutOfOrderRooms int    `json:"out_of_order_rooms"`
+	AvailableRooms  int    `json:"available_rooms"`
+	Price           int    `json:"price,omitempty"`
+	Fallback        bool   `json:"fallback,omitempty"`
+	NoRate          bool   `json:"no_rate,omitempty"`
+}
+
+//AvailabilityCalendarResponse lists every date from the from date until the night before the to date with the nights that can't be sold for lack of price
+type AvailabilityCalendarResponse struct {
+	HotelID      int                `json:"hotel_id"`
+	RoomTypeID   int                `json:"room_type_id"`
+	FromDate     string             `json:"from_date"`
+	ToDate       string             `json:"to_date"`
+	Days         []*AvailabilityDay `json:"days"`
+	MissingDates []string           `json:"missing_dates"`
+}
@@ -1,7 +1,44 @@
 package models
 
+//error codes of ErrResponse, clients branch on them instead of the message
+const (
+	//request body, path or query can't be read
+	ErrorCodeBadRequest = "bad_request"
+	//request rejected by validation, the errors list which field broke which rule
+	ErrorCodeValidation = "validation_failed"
+	//admin token is missing or invalid
+	ErrorCodeUnauthorized = "unauthorized"
+	ErrorCodeNotFound     = "not_found"
+	//fewer rooms than requested are free for the stay
+	ErrorCodeNoAvailability = "no_availability"
+	//room nights were sold by another booking meanwhile, searching again may help
+	ErrorCodeRoomUnavailable = "room_unavailable"
+	ErrorCodePromoNotFound   = "promo_not_found"
+	//promo rules don't allow the request, details tell the promo and the reason
+	ErrorCodePromoIneligible    = "promo_ineligible"
+	ErrorCodePromoFullyRedeemed = "promo_fully_redeemed"
+	ErrorCodePromoCustomerLimit = "promo_customer_limit"
+	//a night of the stay has no price and the room type has no base rate
+	ErrorCodeRateNotLoaded = "rate_not_loaded"
+	//the request conflicts with the current state of the reservation, order, room or block
+	ErrorCodeConflict = "conflict"
+	//a failure on our side, the request may be sent again later
+	ErrorCodeInternal = "internal_error"
+)
+
 type ErrResponse struct {
-	Success bool   `json:"success"`
-	Status  int    `json:"status"`
+	Success   bool                   `json:"success"`
+	Status    int                    `json:"status"`
+	Message   string                 `json:"message"`
+	Retryable bool                   `json:"retryable,omitempty"`
+	ErrorCode string                 `json:"error_code,omitempty"`
+	Errors    []*FieldError          `json:"errors,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
+
+//FieldError tells which request field broke which validation rule
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
 	Message string `json:"message"`
 }
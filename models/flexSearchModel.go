@@ -0,0 +1,33 @@
+package models
+
+//status of a check-in date of the flexible date search
+const (
+	FlexStayAvailable = "available"
+	//fewer rooms than requested are free on some night of the stay
+	FlexStaySoldOut = "sold_out"
+	//some night of the stay has no price and the room type has no base rate
+	FlexStayNoRate = "no_rate"
+	//check-in date is beyond the booking horizon of the hotel
+	FlexStayClosed = "closed"
+)
+
+//FlexStay is one check-in date of the flexible date search, total price is set for available stays only
+type FlexStay struct {
+	CheckinDate        string `json:"checkin_date"`
+	CheckoutDate       string `json:"checkout_date"`
+	Status             string `json:"status"`
+	AvailableRoomCount int    `json:"available_room_count"`
+	TotalPrice         int    `json:"total_price,omitempty"`
+}
+
+//FlexStaysResponse lists the available stays from the cheapest, the calendar has every check-in date of the window in date order
+type FlexStaysResponse struct {
+	HotelID    int         `json:"hotel_id"`
+	RoomTypeID int         `json:"room_type_id"`
+	RoomQty    int         `json:"room_qty"`
+	Nights     int         `json:"nights"`
+	FromDate   string      `json:"from_date"`
+	ToDate     string      `json:"to_date"`
+	Stays      []*FlexStay `json:"stays"`
+	Calendar   []*FlexStay `json:"calendar"`
+}
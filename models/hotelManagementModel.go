@@ -1,23 +1,46 @@
 package models
 
+import "time"
+
+//Hotel stay rules are off when they are 0, booking horizon is how many days ahead a checkin date can be booked
 type Hotel struct {
-	ID        int    `gorm:"primary_key" json:"-"`
-	HotelName string `gorm:"type:varchar(100)" json:"hotel_name"`
-	Address   string `gorm:"type:varchar(500)" json:"address"`
+	ID             int        `gorm:"primary_key" json:"id"`
+	HotelName      string     `gorm:"type:varchar(100)" json:"hotel_name"`
+	Address        string     `gorm:"type:varchar(500)" json:"address"`
+	Timezone       string     `gorm:"type:varchar(50);default:'UTC'" json:"timezone"`
+	MinStayNights  int        `gorm:"default:0" json:"min_stay_nights"`
+	MaxStayNights  int        `gorm:"default:0" json:"max_stay_nights"`
+	BookingHorizon int        `gorm:"default:0" json:"booking_horizon_days"`
+	DeactivatedAt  *time.Time `json:"deactivated_at,omitempty"`
 }
 
+//housekeeping status of a room, out-of-order rooms can't be sold while the status dates last
+const (
+	RoomStatusClean        = "clean"
+	RoomStatusDirty        = "dirty"
+	RoomStatusInspected    = "inspected"
+	RoomStatusOutOfOrder   = "out-of-order"
+	RoomStatusOutOfService = "out-of-service"
+)
+
 type Room struct {
-	ID         int      `gorm:"primary_key" json:"room_id"`
-	HotelID    int      `gorm:"hotel_id" json:"-"`
-	RoomTypeID int      `gorm:"room_type_id" json:"-"`
-	RoomNumber int      `gorm:"default:0" json:"room_number"`
-	RoomStatus string   `json:"-"`
-	Price      []*Price `gorm:"foreignkey:RoomTypeID" json:"price"`
+	ID              int        `gorm:"primary_key" json:"room_id"`
+	HotelID         int        `gorm:"hotel_id" json:"-"`
+	RoomTypeID      int        `gorm:"room_type_id" json:"-"`
+	RoomNumber      int        `gorm:"default:0" json:"room_number"`
+	RoomStatus      string     `gorm:"type:varchar(20);default:'clean'" json:"room_status"`
+	StatusFromDate  *time.Time `gorm:"type:date" json:"status_from_date,omitempty"`
+	StatusUntilDate *time.Time `gorm:"type:date" json:"status_until_date,omitempty"`
+	DeactivatedAt   *time.Time `json:"-"`
+	Price           []*Price   `gorm:"foreignkey:RoomTypeID" json:"price"`
 }
 
+//RoomType prices nights without a price at its base rate, nights can't be sold without price when the base rate is 0
 type RoomType struct {
-	ID   int    `gorm:"primary_key" json:"id"`
-	Name string `gorm:"type:varchar(50)" json:"name"`
+	ID            int        `gorm:"primary_key" json:"id"`
+	Name          string     `gorm:"type:varchar(50)" json:"name"`
+	BaseRate      int        `gorm:"default:0" json:"base_rate"`
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
 }
 
 type Price struct {
@@ -25,18 +48,28 @@ type Price struct {
 	HotelID    int    `gorm:"hotel_id" json:"-"`
 	RoomTypeID int    `gorm:"room_type_id" json:"-"`
 	Price      int    `gorm:"default:0" json:"price"`
+	Fallback   bool   `gorm:"-" json:"fallback,omitempty"`
 }
 
 type Reservation struct {
-	ID              int    `gorm:"primary_key" json:"id"`
-	Order           Order  `gorm:"foreignkey:OrderID"`
-	OrderID         int    `gorm:"order_id" json:"-"`
-	CustomerName    string `gorm:"type:varchar(50)" json:"customerName" binding:"required"`
-	BookedRoomCount int    `gorm:"default:0" json:"bookedRoomCount"`
-	CheckinDate     string `gorm:"type:date" json:"checkinDate"`
-	CheckoutDate    string `gorm:"type:date" json:"checkoutDate"`
-	Hotel           Hotel  `gorm:"foreignkey:HotelID"`
-	HotelID         int    `gorm:"hotel_id" json:"-"`
+	ID                   int    `gorm:"primary_key" json:"id"`
+	Order                Order  `gorm:"foreignkey:OrderID"`
+	OrderID              int    `gorm:"order_id" json:"-"`
+	CustomerName         string `gorm:"type:varchar(50)" json:"customerName" binding:"required"`
+	BookedRoomCount      int    `gorm:"default:0" json:"bookedRoomCount"`
+	CheckinDate          string `gorm:"type:date" json:"checkinDate"`
+	CheckoutDate         string `gorm:"type:date" json:"checkoutDate"`
+	Hotel                Hotel  `gorm:"foreignkey:HotelID"`
+	HotelID              int    `gorm:"hotel_id" json:"-"`
+	RoomTypeID           int    `gorm:"default:0" json:"roomTypeId"`
+	CancellationPolicyID int    `gorm:"default:0" json:"-"`
+	PromoIDs             []int  `gorm:"-" json:"promoIds"`
+	//terms of the cancellation policy agreed on booking, later changes of the policy don't apply to the reservation
+	CancellationTermsSaved bool   `gorm:"default:false" json:"-"`
+	CancellationPolicyName string `gorm:"type:varchar(100)" json:"-"`
+	FreeCancellationDays   int    `gorm:"default:0" json:"-"`
+	CancellationPenalty    string `gorm:"type:varchar(20)" json:"-"`
+	NonRefundable          bool   `gorm:"default:false" json:"-"`
 }
 
 type OrderStatus struct {
@@ -45,10 +78,13 @@ type OrderStatus struct {
 }
 
 type Order struct {
-	ID            int         `gorm:"primary_key" json:"id"`
-	FinalPrice    int         `gorm:"default:0" json:"finalPrice"`
-	OrderStatus   OrderStatus `gorm:"foreignkey:OrderStatusID"`
-	OrderStatusID int         `gorm:"order_status_id" json:"-"`
+	ID         int `gorm:"primary_key" json:"id"`
+	FinalPrice int `gorm:"default:0" json:"finalPrice"`
+	//price paid for the first night of every booked room, charged by first night cancellation penalties
+	FirstNightPrice int         `gorm:"default:0" json:"firstNightPrice"`
+	CancellationFee int         `gorm:"default:0" json:"cancellationFee"`
+	OrderStatus     OrderStatus `gorm:"foreignkey:OrderStatusID"`
+	OrderStatusID   int         `gorm:"order_status_id" json:"-"`
 }
 
 type Stay struct {
@@ -58,6 +94,8 @@ type Stay struct {
 	GuestName     string      `gorm:"type:varchar(50)" json:"guestName" binding:"required"`
 	Room          Room        `gorm:"foreignkey:RoomID"`
 	RoomID        int         `gorm:"room_id" json:"-"`
+	CheckedInAt   *time.Time  `json:"checkedInAt"`
+	CheckedOutAt  *time.Time  `json:"checkedOutAt"`
 }
 
 type StayRoom struct {
@@ -70,16 +108,45 @@ type StayRoom struct {
 }
 
 type Promo struct {
-	ID                int  `gorm:"primary_key" json:"id"`
-	MinimumNights     int  `gorm:"default:0" json:"minimum_nigths"`
-	MinimumRooms      int  `gorm:"default:0" json:"minimum_rooms"`
-	StayDayPromoID    int  `gorm:"stay_day_promo_id" json:"-"`
-	BookingDayPromoID int  `gorm:"booking_day_promo_id" json:"-"`
-	BookingHourFirst  int  `gorm:"default:0" json:"booking_hour_first"`
-	BookingHourLast   int  `gorm:"default:0" json:"booking_hour_last"`
-	IsPercentage      bool `gorm:"default:false" json:"is_percentage"`
-	Percentage        int  `gorm:"default:0" json:"percentage"`
-	Currency          int  `gorm:"default:0" json:"currency"`
+	ID                        int        `gorm:"primary_key" json:"id"`
+	Code                      *string    `gorm:"type:varchar(50);unique_index" json:"code"`
+	MinimumNights             int        `gorm:"default:0" json:"minimum_nigths"`
+	MinimumRooms              int        `gorm:"default:0" json:"minimum_rooms"`
+	StayDayPromoID            int        `gorm:"stay_day_promo_id" json:"-"`
+	BookingDayPromoID         int        `gorm:"booking_day_promo_id" json:"-"`
+	BookingHourFirst          int        `gorm:"default:0" json:"booking_hour_first"`
+	BookingHourLast           int        `gorm:"default:0" json:"booking_hour_last"`
+	IsPercentage              bool       `gorm:"default:false" json:"is_percentage"`
+	Percentage                int        `gorm:"default:0" json:"percentage"`
+	Currency                  int        `gorm:"default:0" json:"currency"`
+	BookingValidFrom          *time.Time `gorm:"type:date" json:"booking_valid_from"`
+	BookingValidUntil         *time.Time `gorm:"type:date" json:"booking_valid_until"`
+	StayValidFrom             *time.Time `gorm:"type:date" json:"stay_valid_from"`
+	StayValidUntil            *time.Time `gorm:"type:date" json:"stay_valid_until"`
+	MaxRedemptions            int        `gorm:"default:0" json:"max_redemptions"`
+	MaxRedemptionsPerCustomer int        `gorm:"default:0" json:"max_redemptions_per_customer"`
+	RedemptionCount           int        `gorm:"default:0" json:"redemption_count"`
+	IsExclusive               bool       `gorm:"default:false" json:"is_exclusive"`
+	StackingGroup             int        `gorm:"default:0" json:"stacking_group"`
+	Priority                  int        `gorm:"default:0" json:"priority"`
+	MinimumNightPrice         int        `gorm:"default:0" json:"minimum_night_price"`
+}
+
+type PromoRedemption struct {
+	ID            int       `gorm:"primary_key" json:"id"`
+	PromoID       int       `gorm:"index" json:"promo_id"`
+	ReservationID int       `gorm:"reservation_id" json:"reservation_id"`
+	CustomerName  string    `gorm:"type:varchar(50);index" json:"customer_name"`
+	CustomerKey   string    `gorm:"type:varchar(50);index" json:"-"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type OrderHistory struct {
+	ID         int       `gorm:"primary_key" json:"id"`
+	OrderID    int       `gorm:"index" json:"order_id"`
+	FromStatus string    `gorm:"type:varchar(20)" json:"from_status"`
+	ToStatus   string    `gorm:"type:varchar(20)" json:"to_status"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 type StayDayPromo struct {
@@ -105,31 +172,65 @@ type BookingDayPromo struct {
 }
 
 type HotelAvailableRoomsResponse struct {
-	RoomQty        int     `json:"room_qty"`
-	RoomTypeID     int     `json:"room_type_id"`
-	CheckinDate    string  `json:"checkin_date"`
-	CheckoutDate   string  `json:"checkout_date"`
-	TotalPrice     int     `json:"total_price"`
-	AvailableRooms []*Room `json:"available_rooms"`
+	HotelID            int                 `json:"hotel_id"`
+	RoomQty            int                 `json:"room_qty"`
+	RoomTypeID         int                 `json:"room_type_id"`
+	CheckinDate        string              `json:"checkin_date"`
+	CheckoutDate       string              `json:"checkout_date"`
+	TotalPrice         int                 `json:"total_price"`
+	CancellationPolicy *CancellationPolicy `json:"cancellation_policy"`
+	AvailableRooms     []*Room             `json:"available_rooms"`
 }
 
 type PromoRoomsRequest struct {
-	PromoID        int     `json:"promo_id"`
-	RoomQty        int     `json:"room_qty"`
-	RoomTypeID     int     `json:"room_type_id"`
-	CheckinDate    string  `json:"checkin_date"`
-	CheckoutDate   string  `json:"checkout_date"`
-	TotalPrice     int     `json:"total_price"`
-	AvailableRooms []*Room `json:"available_rooms"`
+	PromoID      int      `json:"promo_id"`
+	PromoCode    string   `json:"promo_code"`
+	PromoIDs     []int    `json:"promo_ids"`
+	PromoCodes   []string `json:"promo_codes"`
+	HotelID      int      `json:"hotel_id" binding:"required"`
+	RoomQty      int      `json:"room_qty" binding:"required"`
+	RoomTypeID   int      `json:"room_type_id" binding:"required"`
+	CheckinDate  string   `json:"checkin_date" binding:"required"`
+	CheckoutDate string   `json:"checkout_date" binding:"required"`
 }
 
 type PromoRoomsResponse struct {
-	PromoID        int     `json:"promo_id"`
-	RoomQty        int     `json:"room_qty"`
-	RoomTypeID     int     `json:"room_type_id"`
-	CheckinDate    string  `json:"checkin_date"`
-	CheckoutDate   string  `json:"checkout_date"`
-	PromoPrice     int     `json:"promo_price"`
-	TotalPrice     int     `json:"total_price"`
-	AvailableRooms []*Room `json:"available_rooms"`
+	PromoID        int                  `json:"promo_id"`
+	PromoCode      string               `json:"promo_code"`
+	HotelID        int                  `json:"hotel_id"`
+	RoomQty        int                  `json:"room_qty"`
+	RoomTypeID     int                  `json:"room_type_id"`
+	CheckinDate    string               `json:"checkin_date"`
+	CheckoutDate   string               `json:"checkout_date"`
+	PromoPrice     int                  `json:"promo_price"`
+	TotalPrice     int                  `json:"total_price"`
+	Promos         []*PromoContribution `json:"promos"`
+	AvailableRooms []*Room              `json:"available_rooms"`
+}
+
+type PromoContribution struct {
+	PromoID    int    `json:"promo_id"`
+	PromoCode  string `json:"promo_code"`
+	PromoPrice int    `json:"promo_price"`
+}
+
+type BestPromoRoomsResponse struct {
+	HotelID      int                   `json:"hotel_id"`
+	RoomQty      int                   `json:"room_qty"`
+	RoomTypeID   int                   `json:"room_type_id"`
+	CheckinDate  string                `json:"checkin_date"`
+	CheckoutDate string                `json:"checkout_date"`
+	TotalPrice   int                   `json:"total_price"`
+	BestPrice    int                   `json:"best_price"`
+	BestPromoID  int                   `json:"best_promo_id"`
+	Promos       []*PromoRoomsResponse `json:"promos"`
+}
+
+//PromoRuleFailure tells which promo rule a request broke and how to qualify, have and need are set for minimum rules
+type PromoRuleFailure struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	Have    int    `json:"have,omitempty"`
+	Need    int    `json:"need,omitempty"`
+	Hint    string `json:"hint,omitempty"`
 }
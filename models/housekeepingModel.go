@@ -0,0 +1,41 @@
+package models
+
+//occupancy of a room on the task board day
+const (
+	OccupancyVacant    = "vacant"
+	OccupancyArrival   = "arrival"
+	OccupancyDeparture = "departure"
+	OccupancyTurnover  = "turnover"
+	OccupancyStayover  = "stayover"
+)
+
+//housekeeping tasks of the task board
+const (
+	TaskNone        = "none"
+	TaskClean       = "clean"
+	TaskService     = "service"
+	TaskInspect     = "inspect"
+	TaskMaintenance = "maintenance"
+)
+
+//RoomStatusRequest sets room status, from and until dates only apply to out-of-order and out-of-service
+type RoomStatusRequest struct {
+	Status    string `json:"status" binding:"required"`
+	FromDate  string `json:"from_date"`
+	UntilDate string `json:"until_date"`
+}
+
+type HousekeepingTask struct {
+	RoomID     int    `json:"room_id"`
+	RoomNumber int    `json:"room_number"`
+	RoomTypeID int    `json:"room_type_id"`
+	RoomStatus string `json:"room_status"`
+	Occupancy  string `json:"occupancy"`
+	Task       string `json:"task"`
+}
+
+type HousekeepingBoardResponse struct {
+	HotelID int                 `json:"hotel_id"`
+	Date    string              `json:"date"`
+	Tasks   []*HousekeepingTask `json:"tasks"`
+}
@@ -0,0 +1,57 @@
+package models
+
+//RoomLine asks for a number of rooms of one room type
+type RoomLine struct {
+	RoomTypeID int `json:"room_type_id" example:"1"`
+	RoomQty    int `json:"room_qty" example:"1"`
+}
+
+//MultiRoomSearchRequest searches every line for the same hotel and stay dates, a room type can be listed once
+type MultiRoomSearchRequest struct {
+	HotelID      int         `json:"hotel_id" binding:"required"`
+	CheckinDate  string      `json:"checkin_date" binding:"required"`
+	CheckoutDate string      `json:"checkout_date" binding:"required"`
+	Lines        []*RoomLine `json:"lines" binding:"required"`
+}
+
+//MultiRoomLine tells whether the rooms of one line are free, total price is the price of every requested room of the line
+type MultiRoomLine struct {
+	RoomTypeID         int                 `json:"room_type_id"`
+	RoomQty            int                 `json:"room_qty"`
+	AvailableRoomCount int                 `json:"available_room_count"`
+	Available          bool                `json:"available"`
+	TotalPrice         int                 `json:"total_price"`
+	CancellationPolicy *CancellationPolicy `json:"cancellation_policy"`
+	AvailableRooms     []*Room             `json:"available_rooms"`
+}
+
+//MultiRoomSearchResponse is available when every line is available, total price adds up the lines
+type MultiRoomSearchResponse struct {
+	HotelID      int              `json:"hotel_id"`
+	CheckinDate  string           `json:"checkin_date"`
+	CheckoutDate string           `json:"checkout_date"`
+	RoomQty      int              `json:"room_qty"`
+	Available    bool             `json:"available"`
+	TotalPrice   int              `json:"total_price"`
+	Lines        []*MultiRoomLine `json:"lines"`
+}
+
+//MultiRoomReservationRequest books every line or none of them
+type MultiRoomReservationRequest struct {
+	CustomerName string      `json:"customer_name" binding:"required"`
+	HotelID      int         `json:"hotel_id" binding:"required"`
+	CheckinDate  string      `json:"checkin_date" binding:"required"`
+	CheckoutDate string      `json:"checkout_date" binding:"required"`
+	Lines        []*RoomLine `json:"lines" binding:"required"`
+}
+
+//MultiRoomReservationResponse has one reservation for each line
+type MultiRoomReservationResponse struct {
+	CustomerName string                 `json:"customer_name"`
+	HotelID      int                    `json:"hotel_id"`
+	CheckinDate  string                 `json:"checkin_date"`
+	CheckoutDate string                 `json:"checkout_date"`
+	RoomQty      int                    `json:"room_qty"`
+	FinalPrice   int                    `json:"final_price"`
+	Reservations []*ReservationResponse `json:"reservations"`
+}
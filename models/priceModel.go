@@ -0,0 +1,27 @@
+package models
+
+type PriceRangeRequest struct {
+	FromDate        string         `json:"from_date" binding:"required" example:"2022-12-01"`
+	ToDate          string         `json:"to_date" binding:"required" example:"2023-01-01"`
+	Price           int            `json:"price" binding:"required" example:"500000"`
+	DayOfWeekPrices map[string]int `json:"day_of_week_prices" example:"friday:600000,saturday:600000"`
+}
+
+//PriceCalendarResponse lists nightly prices from the from date until the night before the to date with the nights that have no price
+type PriceCalendarResponse struct {
+	HotelID      int      `json:"hotel_id"`
+	RoomTypeID   int      `json:"room_type_id"`
+	FromDate     string   `json:"from_date"`
+	ToDate       string   `json:"to_date"`
+	Prices       []*Price `json:"prices"`
+	MissingDates []string `json:"missing_dates"`
+}
+
+//RateNotLoadedResponse lists the nights of the stay which have no price
+type RateNotLoadedResponse struct {
+	Success      bool     `json:"success"`
+	Status       int      `json:"status"`
+	Message      string   `json:"message"`
+	ErrorCode    string   `json:"error_code,omitempty"`
+	MissingDates []string `json:"missing_dates"`
+}
@@ -0,0 +1,149 @@
+package models
+
+import "time"
+
+//order lifecycle, pending -> confirmed -> checked-in -> checked-out, an open order can end up cancelled or no-show
+const (
+	OrderStatusPending    = "pending"
+	OrderStatusConfirmed  = "confirmed"
+	OrderStatusCheckedIn  = "checked-in"
+	OrderStatusCheckedOut = "checked-out"
+	OrderStatusCancelled  = "cancelled"
+	OrderStatusNoShow     = "no-show"
+)
+
+//ReservationRequest books rooms of a room type, the price is always computed on booking and never taken from the request
+type ReservationRequest struct {
+	CustomerName   string   `json:"customer_name" binding:"required"`
+	HotelID        int      `json:"hotel_id" binding:"required"`
+	PromoID        int      `json:"promo_id"`
+	PromoCode      string   `json:"promo_code"`
+	PromoIDs       []int    `json:"promo_ids"`
+	PromoCodes     []string `json:"promo_codes"`
+	RoomQty        int      `json:"room_qty" binding:"required"`
+	RoomTypeID     int      `json:"room_type_id" binding:"required"`
+	CheckinDate    string   `json:"checkin_date" binding:"required"`
+	CheckoutDate   string   `json:"checkout_date" binding:"required"`
+	AvailableRooms []*Room  `json:"available_rooms"`
+}
+
+type ReservationResponse struct {
+	ReservationID int                  `json:"reservation_id"`
+	OrderID       int                  `json:"order_id"`
+	OrderStatus   string               `json:"order_status"`
+	CustomerName  string               `json:"customer_name"`
+	HotelID       int                  `json:"hotel_id"`
+	PromoID       int                  `json:"promo_id,omitempty"`
+	PromoCode     string               `json:"promo_code,omitempty"`
+	Promos        []*PromoContribution `json:"promos,omitempty"`
+	RoomQty       int                  `json:"room_qty"`
+	RoomTypeID    int                  `json:"room_type_id"`
+	CheckinDate   string               `json:"checkin_date"`
+	CheckoutDate  string               `json:"checkout_date"`
+	FinalPrice    int                  `json:"final_price"`
+	Rooms         []*Room              `json:"rooms"`
+}
+
+const (
+	PenaltyFirstNight = "first_night"
+	PenaltyFullStay   = "full_stay"
+)
+
+//CancellationPolicy of a hotel, or of one room type of the hotel when RoomTypeID is set
+type CancellationPolicy struct {
+	ID                   int    `gorm:"primary_key" json:"id"`
+	HotelID              int    `gorm:"index" json:"hotel_id"`
+	RoomTypeID           int    `gorm:"default:0" json:"room_type_id"`
+	Name                 string `gorm:"type:varchar(100)" json:"name"`
+	FreeCancellationDays int    `gorm:"default:0" json:"free_cancellation_days"`
+	Penalty              string `gorm:"type:varchar(20)" json:"penalty"`
+	NonRefundable        bool   `gorm:"default:false" json:"non_refundable"`
+}
+
+type CancellationResponse struct {
+	ReservationID      int                 `json:"reservation_id"`
+	OrderID            int                 `json:"order_id"`
+	OrderStatus        string              `json:"order_status"`
+	CheckinDate        string              `json:"checkin_date"`
+	CheckoutDate       string              `json:"checkout_date"`
+	FinalPrice         int                 `json:"final_price"`
+	CancellationFee    int                 `json:"cancellation_fee"`
+	RefundAmount       int                 `json:"refund_amount"`
+	CancellationPolicy *CancellationPolicy `json:"cancellation_policy"`
+}
+
+//ReservationUpdateRequest changes only the fields that are set
+type ReservationUpdateRequest struct {
+	RoomQty      int    `json:"room_qty"`
+	RoomTypeID   int    `json:"room_type_id"`
+	CheckinDate  string `json:"checkin_date"`
+	CheckoutDate string `json:"checkout_date"`
+}
+
+type ReservationCharge struct {
+	RoomQty      int    `json:"room_qty"`
+	RoomTypeID   int    `json:"room_type_id"`
+	CheckinDate  string `json:"checkin_date"`
+	CheckoutDate string `json:"checkout_date"`
+	PromoIDs     []int  `json:"promo_ids"`
+	FinalPrice   int    `json:"final_price"`
+}
+
+type RoomNight struct {
+	RoomID int    `json:"room_id"`
+	Date   string `json:"date"`
+}
+
+type ReservationUpdateResponse struct {
+	ReservationID   int                `json:"reservation_id"`
+	OrderID         int                `json:"order_id"`
+	Old             *ReservationCharge `json:"old"`
+	New             *ReservationCharge `json:"new"`
+	PriceDifference int                `json:"price_difference"`
+	AddedNights     []*RoomNight       `json:"added_nights"`
+	ReleasedNights  []*RoomNight       `json:"released_nights"`
+	RemovedPromoIDs []int              `json:"removed_promo_ids"`
+	Rooms           []*Room            `json:"rooms"`
+}
+
+type OrderStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+}
+
+type OrderHistoryResponse struct {
+	OrderID     int             `json:"order_id"`
+	OrderStatus string          `json:"order_status"`
+	History     []*OrderHistory `json:"history"`
+}
+
+type CheckInGuest struct {
+	GuestName  string `json:"guest_name" binding:"required"`
+	RoomNumber int    `json:"room_number"`
+}
+
+//CheckInRequest names the guest of each booked room, guests without room number get the next unassigned room
+type CheckInRequest struct {
+	Guests []*CheckInGuest `json:"guests" binding:"required,dive"`
+}
+
+type StayResponse struct {
+	StayID       int        `json:"stay_id"`
+	RoomID       int        `json:"room_id"`
+	RoomNumber   int        `json:"room_number"`
+	GuestName    string     `json:"guest_name"`
+	CheckedInAt  *time.Time `json:"checked_in_at"`
+	CheckedOutAt *time.Time `json:"checked_out_at"`
+}
+
+//FrontDeskResponse keeps the final price of the whole booked stay on an early check-out,
+//the released nights go back on sale without refund
+type FrontDeskResponse struct {
+	ReservationID  int             `json:"reservation_id"`
+	OrderID        int             `json:"order_id"`
+	OrderStatus    string          `json:"order_status"`
+	CheckinDate    string          `json:"checkin_date"`
+	CheckoutDate   string          `json:"checkout_date"`
+	FinalPrice     int             `json:"final_price"`
+	Stays          []*StayResponse `json:"stays"`
+	ReleasedNights []*RoomNight    `json:"released_nights,omitempty"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+//RoomBlock takes a room out of inventory from the from date until the night before the until date
+type RoomBlock struct {
+	ID        int       `gorm:"primary_key" json:"id"`
+	Room      Room      `gorm:"foreignkey:RoomID" json:"-"`
+	RoomID    int       `gorm:"index" json:"room_id"`
+	FromDate  string    `gorm:"type:date" json:"from_date"`
+	UntilDate string    `gorm:"type:date" json:"until_date"`
+	Reason    string    `gorm:"type:varchar(255)" json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type RoomBlockRequest struct {
+	RoomID    int    `json:"room_id" binding:"required"`
+	FromDate  string `json:"from_date" binding:"required"`
+	UntilDate string `json:"until_date" binding:"required"`
+	Reason    string `json:"reason" binding:"required"`
+}
+
+//RoomBlockConflictResponse lists the sold room nights that stop a room from being blocked
+type RoomBlockConflictResponse struct {
+	Success   bool         `json:"success"`
+	Status    int          `json:"status"`
+	Message   string       `json:"message"`
+	ErrorCode string       `json:"error_code,omitempty"`
+	Conflicts []*RoomNight `json:"conflicts"`
+}
+
+type BlockedRoomNight struct {
+	BlockID    int    `json:"block_id"`
+	RoomID     int    `json:"room_id"`
+	RoomNumber int    `json:"room_number"`
+	Date       string `json:"date"`
+	Reason     string `json:"reason"`
+}
+
+type RoomBlockReportResponse struct {
+	HotelID       int                 `json:"hotel_id"`
+	FromDate      string              `json:"from_date"`
+	ToDate        string              `json:"to_date"`
+	BlockedNights int                 `json:"blocked_nights"`
+	Nights        []*BlockedRoomNight `json:"nights"`
+}
@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/jinzhu/gorm"
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+//ErrRoomNumberTaken is returned when another room of the hotel already has the room number
+var ErrRoomNumberTaken = errors.New("room number is already used by another room of the hotel")
+
+//fetching all room types
+func (repo *hotelMgmtRepo) FindRoomTypes() (roomTypes []*models.RoomType, err error) {
+	if err = repo.connection.Debug().Order("id").Find(&roomTypes).Error; err != nil {
+		return nil, err
+	}
+	return roomTypes, nil
+}
+
+//fetching room type by id
+func (repo *hotelMgmtRepo) FindRoomTypeByID(id int) (*models.RoomType, error) {
+	var roomType models.RoomType
+	if err := repo.connection.Debug().Where("id = ?", id).First(&roomType).Error; err != nil {
+		return nil, err
+	}
+	return &roomType, nil
+}
+
+//fetching every room of a room type in all hotels
+func (repo *hotelMgmtRepo) FindRoomTypeRooms(roomTypeID int) (rooms []*models.Room, err error) {
+	if err = repo.connection.Debug().Where("room_type_id = ?", roomTypeID).Order("hotel_id, room_number, id").Find(&rooms).Error; err != nil {
+		return nil, err
+	}
+	return rooms, nil
+}
+
+//create hotel
+func (repo *hotelMgmtRepo) CreateHotel(hotel *models.Hotel) error {
+	return repo.connection.Debug().Create(hotel).Error
+}
+
+//update name, address, timezone, stay rules and deactivation time of a hotel
+func (repo *hotelMgmtRepo) UpdateHotel(hotel *models.Hotel) error {
+	return repo.connection.Debug().Model(&models.Hotel{}).Where("id = ?", hotel.ID).Updates(map[string]interface{}{
+		"hotel_name":      hotel.HotelName,
+		"address":         hotel.Address,
+		"timezone":        hotel.Timezone,
+		"min_stay_nights": hotel.MinStayNights,
+		"max_stay_nights": hotel.MaxStayNights,
+		"booking_horizon": hotel.BookingHorizon,
+		"deactivated_at":  hotel.DeactivatedAt,
+	}).Error
+}
+
+//create room type
+func (repo *hotelMgmtRepo) CreateRoomType(roomType *models.RoomType) error {
+	return repo.connection.Debug().Create(roomType).Error
+}
+
+//update name, base rate and deactivation time of a room type
+func (repo *hotelMgmtRepo) UpdateRoomType(roomType *models.RoomType) error {
+	return repo.connection.Debug().Model(&models.RoomType{}).Where("id = ?", roomType.ID).Updates(map[string]interface{}{
+		"name":           roomType.Name,
+		"base_rate":      roomType.BaseRate,
+		"deactivated_at": roomType.DeactivatedAt,
+	}).Error
+}
+
+//create room, the room number must be free in the hotel
+func (repo *hotelMgmtRepo) CreateRoom(room *models.Room) (err error) {
+	tx := repo.connection.Debug().Set("gorm:save_associations", false).Begin()
+	if err = tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = checkRoomNumber(tx, room); err != nil {
+		return err
+	}
+	if room.RoomStatus == "" {
+		room.RoomStatus = models.RoomStatusClean
+	}
+	if err = tx.Create(room).Error; err != nil {
+		//a concurrent request took the room number after the check
+		if isUniqueViolation(err) {
+			err = ErrRoomNumberTaken
+		}
+		return err
+	}
+	return tx.Commit().Error
+}
+
+//update hotel, room type, room number and deactivation time of a room, the room number must be free in the hotel
+func (repo *hotelMgmtRepo) UpdateRoom(room *models.Room) (err error) {
+	tx := repo.connection.Debug().Begin()
+	if err = tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = checkRoomNumber(tx, room); err != nil {
+		return err
+	}
+	if err = tx.Model(&models.Room{}).Where("id = ?", room.ID).Updates(map[string]interface{}{
+		"hotel_id":       room.HotelID,
+		"room_type_id":   room.RoomTypeID,
+		"room_number":    room.RoomNumber,
+		"deactivated_at": room.DeactivatedAt,
+	}).Error; err != nil {
+		//a concurrent request took the room number after the check
+		if isUniqueViolation(err) {
+			err = ErrRoomNumberTaken
+		}
+		return err
+	}
+	return tx.Commit().Error
+}
+
+//check no other room of the hotel has the room number
+func checkRoomNumber(tx *gorm.DB, room *models.Room) error {
+	var count int
+	if err := tx.Model(&models.Room{}).Where("hotel_id = ? AND room_number = ? AND id <> ?", room.HotelID, room.RoomNumber, room.ID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrRoomNumberTaken
+	}
+	return nil
+}
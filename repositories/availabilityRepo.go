@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+//counting active rooms of a hotel and room type
+func (repo *hotelMgmtRepo) CountRooms(hotelID int, roomTypeID int) (count int, err error) {
+	if err = repo.connection.Debug().Model(&models.Room{}).
+		Where("hotel_id = ? AND room_type_id = ? AND deactivated_at IS NULL", hotelID, roomTypeID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+//counting rooms of a hotel and room type sold on each date between from date and to date, dates without sold rooms are left out
+func (repo *hotelMgmtRepo) CountSoldRooms(hotelID int, roomTypeID int, fromDate string, toDate string) (counts []*models.DateCount, err error) {
+	if err = repo.connection.Debug().Table("stay_rooms").Joins("JOIN rooms ON rooms.id = stay_rooms.room_id").
+		Where("rooms.hotel_id = ? AND rooms.room_type_id = ? AND stay_rooms.date >= ? AND stay_rooms.date < ?", hotelID, roomTypeID, fromDate, toDate).
+		Select("stay_rooms.date AS date, COUNT(DISTINCT stay_rooms.room_id) AS count").
+		Group("stay_rooms.date").Order("stay_rooms.date").Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+//fetching block dates of every blocked room of a hotel and room type, only blocks overlapping from date until to date are fetched
+func (repo *hotelMgmtRepo) FindBlockedRoomDates(hotelID int, roomTypeID int, fromDate string, toDate string) (ranges []*models.RoomDateRange, err error) {
+	if err = repo.connection.Debug().Table("room_blocks").Joins("JOIN rooms ON rooms.id = room_blocks.room_id").
+		Where("rooms.hotel_id = ? AND rooms.room_type_id = ? AND room_blocks.from_date < ? AND room_blocks.until_date > ?", hotelID, roomTypeID, toDate, fromDate).
+		Select("room_blocks.room_id AS room_id, room_blocks.from_date AS from_date, room_blocks.until_date AS until_date").
+		Order("room_blocks.from_date, room_blocks.room_id").Scan(&ranges).Error; err != nil {
+		return nil, err
+	}
+	return ranges, nil
+}
+
+//fetching status dates of every active room of a hotel and room type having the housekeeping status, open status dates are empty,
+//status dates are compared with the requested dates by the caller as sqlite keeps them as date time text
+func (repo *hotelMgmtRepo) FindRoomStatusDates(hotelID int, roomTypeID int, status string) (ranges []*models.RoomDateRange, err error) {
+	if err = repo.connection.Debug().Model(&models.Room{}).
+		Where("hotel_id = ? AND room_type_id = ? AND deactivated_at IS NULL AND room_status = ?", hotelID, roomTypeID, status).
+		Select("id AS room_id, COALESCE(status_from_date, '') AS from_date, COALESCE(status_until_date, '') AS until_date").
+		Order("id").Scan(&ranges).Error; err != nil {
+		return nil, err
+	}
+	return ranges, nil
+}
@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+//Fixture holds master data loaded into a fresh database
+type Fixture struct {
+	Hotels           []*models.Hotel
+	RoomTypes        []*models.RoomType
+	Rooms            []*models.Room
+	Prices           []*models.Price
+	Promos           []*models.Promo
+	StayDayPromos    []*models.StayDayPromo
+	BookingDayPromos []*models.BookingDayPromo
+	//CancellationPolicies are attached to a hotel, or to one room type when RoomTypeID is set
+	CancellationPolicies []*models.CancellationPolicy
+}
+
+//choose fixture by DB_SEED value
+func fixtureBySeed(seed string) (*Fixture, error) {
+	switch seed {
+	case "":
+		return nil, nil
+	case "demo":
+		return DemoFixture(time.Now().UTC()), nil
+	}
+	return nil, fmt.Errorf("unsupported DB_SEED %q, use demo", seed)
+}
+
+//DemoFixture is one hotel with single and double rooms priced for a year from the given day
+func DemoFixture(from time.Time) *Fixture {
+	fixture := &Fixture{
+		Hotels: []*models.Hotel{
+			{ID: 1, HotelName: "Demo Hotel", Address: "Jl. Demo No. 1, Jakarta", Timezone: "Asia/Jakarta"},
+		},
+		RoomTypes: []*models.RoomType{
+			{ID: 1, Name: "Single"},
+			{ID: 2, Name: "Double"},
+		},
+		StayDayPromos: []*models.StayDayPromo{
+			{ID: 1, IsFriPromo: true, IsSatPromo: true},
+		},
+		Promos: []*models.Promo{
+			{ID: 1, Code: promoCode("WEEKEND10"), StayDayPromoID: 1, IsPercentage: true, Percentage: 10},
+			{ID: 2, Code: promoCode("LONGSTAY"), MinimumNights: 3, Currency: 50000},
+		},
+		CancellationPolicies: []*models.CancellationPolicy{
+			{ID: 1, HotelID: 1, Name: "Free cancellation until 3 days before check-in", FreeCancellationDays: 3, Penalty: models.PenaltyFirstNight},
+		},
+	}
+
+	baseRates := map[int]int{1: 500000, 2: 750000}
+	for roomTypeID := 1; roomTypeID <= 2; roomTypeID++ {
+		for i := 1; i <= 5; i++ {
+			fixture.Rooms = append(fixture.Rooms, &models.Room{
+				ID:         len(fixture.Rooms) + 1,
+				HotelID:    1,
+				RoomTypeID: roomTypeID,
+				RoomNumber: roomTypeID*100 + i,
+			})
+		}
+		for day := 0; day < 365; day++ {
+			date := from.AddDate(0, 0, day)
+			price := baseRates[roomTypeID]
+			if date.Weekday() == time.Friday || date.Weekday() == time.Saturday {
+				price = price * 120 / 100
+			}
+			fixture.Prices = append(fixture.Prices, &models.Price{
+				Date:       date.Format("2006-01-02"),
+				HotelID:    1,
+				RoomTypeID: roomTypeID,
+				Price:      price,
+			})
+		}
+	}
+	return fixture
+}
+
+//code of a promo applied with a code, promos without code keep a NULL code so any number of them can exist
+func promoCode(code string) *string {
+	return &code
+}
+
+//insert fixture into an empty database
+func seedFixture(db *gorm.DB, fixture *Fixture) error {
+	if fixture == nil {
+		return nil
+	}
+	var hotels int
+	if err := db.Model(&models.Hotel{}).Count(&hotels).Error; err != nil || hotels > 0 {
+		return err
+	}
+
+	tx := db.Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+	var records []interface{}
+	for _, hotel := range fixture.Hotels {
+		records = append(records, hotel)
+	}
+	for _, roomType := range fixture.RoomTypes {
+		records = append(records, roomType)
+	}
+	for _, room := range fixture.Rooms {
+		records = append(records, room)
+	}
+	for _, price := range fixture.Prices {
+		records = append(records, price)
+	}
+	for _, promo := range fixture.StayDayPromos {
+		records = append(records, promo)
+	}
+	for _, promo := range fixture.BookingDayPromos {
+		records = append(records, promo)
+	}
+	for _, promo := range fixture.Promos {
+		records = append(records, promo)
+	}
+	for _, policy := range fixture.CancellationPolicies {
+		records = append(records, policy)
+	}
+	for _, record := range records {
+		if err := tx.Create(record).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
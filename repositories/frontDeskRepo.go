@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+//fetching stays of a reservation together with their rooms
+func (repo *hotelMgmtRepo) FindReservationStays(reservationID int) (stays []*models.Stay, err error) {
+	if err = repo.connection.Debug().Preload("Room").Where("reservation_id = ?", reservationID).
+		Order("id").Find(&stays).Error; err != nil {
+		return nil, err
+	}
+	return stays, nil
+}
+
+//save guest names and arrival time of the stays and move order to checked-in in a single transaction
+func (repo *hotelMgmtRepo) CheckInReservation(reservation *models.Reservation, stays []*models.Stay) (err error) {
+	tx := repo.connection.Debug().Set("gorm:save_associations", false).Begin()
+	if err = tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = setOrderStatus(tx, &reservation.Order, models.OrderStatusCheckedIn); err != nil {
+		return err
+	}
+	for _, stay := range stays {
+		if err = tx.Model(&models.Stay{}).Where("id = ? AND reservation_id = ?", stay.ID, reservation.ID).Updates(map[string]interface{}{
+			"guest_name":    stay.GuestName,
+			"checked_in_at": stay.CheckedInAt,
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+//close the stays, flag their rooms dirty, release nights from the given date and move order to checked-out in a single transaction
+func (repo *hotelMgmtRepo) CheckOutReservation(reservation *models.Reservation, stays []*models.Stay, releaseFromDate string) (err error) {
+	tx := repo.connection.Debug().Set("gorm:save_associations", false).Begin()
+	if err = tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = setOrderStatus(tx, &reservation.Order, models.OrderStatusCheckedOut); err != nil {
+		return err
+	}
+	for _, stay := range stays {
+		if err = tx.Model(&models.Stay{}).Where("id = ? AND reservation_id = ?", stay.ID, reservation.ID).
+			UpdateColumn("checked_out_at", stay.CheckedOutAt).Error; err != nil {
+			return err
+		}
+		//rooms taken out of order keep their status
+		if err = tx.Model(&models.Room{}).Where("id = ? AND room_status NOT IN (?)", stay.RoomID,
+			[]string{models.RoomStatusOutOfOrder, models.RoomStatusOutOfService}).
+			UpdateColumn("room_status", models.RoomStatusDirty).Error; err != nil {
+			return err
+		}
+	}
+	if err = tx.Where("stay_id IN (SELECT id FROM stays WHERE reservation_id = ?) AND date >= ?", reservation.ID, releaseFromDate).
+		Delete(&models.StayRoom{}).Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"fmt"
+	"log"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/jinzhu/gorm"
@@ -10,52 +11,177 @@ import (
 )
 
 type HotelMgmtRepo interface {
-	FindAvailableRoomsByFields(unavailableRoomId []int, fields string, values ...interface{}) (rooms []*models.Room, err error)
-	FindPricesByFields(fields string, values ...interface{}) (prices []*models.Price, err error)
-	FindRoomIDFromStayRoomByFields(fields string, values ...interface{}) (ids []int, err error)
+	FindRooms(hotelID int, roomTypeID int, excludeRoomIDs []int) (rooms []*models.Room, err error)
+	FindHotelRooms(hotelID int) (rooms []*models.Room, err error)
+	FindRoomByID(id int) (*models.Room, error)
+	UpdateRoomStatus(room *models.Room) error
+	FindRoomBlocks(hotelID int, fromDate string, toDate string) (blocks []*models.RoomBlock, err error)
+	FindRoomBlockByID(id int) (*models.RoomBlock, error)
+	CreateRoomBlock(block *models.RoomBlock) error
+	UpdateRoomBlock(block *models.RoomBlock) error
+	DeleteRoomBlock(id int) error
+	FindPrices(hotelID int, roomTypeID int, fromDate string, toDate string) (prices []*models.Price, err error)
+	SavePrices(hotelID int, roomTypeID int, prices []*models.Price) error
+	FindBookedRoomIDs(hotelID int, fromDate string, toDate string) (ids []int, err error)
+	FindStayRooms(hotelID int, fromDate string, toDate string) (stayRooms []*models.StayRoom, err error)
+	CountRooms(hotelID int, roomTypeID int) (count int, err error)
+	CountSoldRooms(hotelID int, roomTypeID int, fromDate string, toDate string) (counts []*models.DateCount, err error)
+	FindBlockedRoomDates(hotelID int, roomTypeID int, fromDate string, toDate string) (ranges []*models.RoomDateRange, err error)
+	FindRoomStatusDates(hotelID int, roomTypeID int, status string) (ranges []*models.RoomDateRange, err error)
+	FindHotels() (hotels []*models.Hotel, err error)
+	FindHotelByID(id int) (*models.Hotel, error)
+	FindRoomTypes() (roomTypes []*models.RoomType, err error)
+	FindRoomTypeByID(id int) (*models.RoomType, error)
+	FindRoomTypeRooms(roomTypeID int) (rooms []*models.Room, err error)
+	CreateHotel(hotel *models.Hotel) error
+	UpdateHotel(hotel *models.Hotel) error
+	CreateRoomType(roomType *models.RoomType) error
+	UpdateRoomType(roomType *models.RoomType) error
+	CreateRoom(room *models.Room) error
+	UpdateRoom(room *models.Room) error
 	FindPromoByID(id int) (*models.Promo, error)
+	FindPromoByCode(code string) (*models.Promo, error)
+	FindPromos() (promos []*models.Promo, err error)
 	FindStayPromoByID(id int) (*models.StayDayPromo, error)
 	FindBookingPromoByID(id int) (*models.BookingDayPromo, error)
+	FindCancellationPolicy(hotelID int, roomTypeID int) (*models.CancellationPolicy, error)
+	FindCancellationPolicyByID(id int) (*models.CancellationPolicy, error)
+	FindReservationByID(id int) (*models.Reservation, error)
+	FindOrderByID(id int) (*models.Order, error)
+	FindOrderHistory(orderID int) (history []*models.OrderHistory, err error)
+	FindReservationStayRooms(reservationID int) (stayRooms []*models.StayRoom, err error)
+	FindReservationStays(reservationID int) (stays []*models.Stay, err error)
+	FindPromoRedemptions(reservationID int) (redemptions []*models.PromoRedemption, err error)
+	CreateReservation(reservation *models.Reservation, status string, roomIDs []int, dates []string) error
+	CreateReservations(reservations []*models.Reservation, status string, roomIDs [][]int, dates []string) error
+	UpdateReservation(reservation *models.Reservation, releaseNights []*models.StayRoom, holdNights []*models.StayRoom, removedPromoIDs []int) error
+	CancelReservation(reservation *models.Reservation, status string, cancellationFee int) error
+	UpdateOrderStatus(order *models.Order, status string) error
+	CheckInReservation(reservation *models.Reservation, stays []*models.Stay) error
+	CheckOutReservation(reservation *models.Reservation, stays []*models.Stay, releaseFromDate string) error
 }
 
 type hotelMgmtRepo struct {
 	connection *gorm.DB
 }
 
+//foreign key of the schema, declared on table creation for sqlite which can't alter constraints
+type foreignKey struct {
+	model interface{}
+	field string
+	dest  string
+}
+
+var foreignKeys = []foreignKey{
+	{&models.Room{}, "hotel_id", "hotels(id)"},
+	{&models.Room{}, "room_type_id", "room_types(id)"},
+	{&models.Price{}, "hotel_id", "hotels(id)"},
+	{&models.Price{}, "room_type_id", "room_types(id)"},
+	{&models.CancellationPolicy{}, "hotel_id", "hotels(id)"},
+	{&models.RoomBlock{}, "room_id", "rooms(id)"},
+}
+
+//create repository for the database driver chosen by DB_DRIVER
 func NewHotelMgmtRepo() HotelMgmtRepo {
+	dbConfig := configs.BuildDBConfig()
+	fixture, err := fixtureBySeed(dbConfig.Seed)
+	if err != nil {
+		log.Fatalf("Warning: %v\n", err)
+	}
+
+	switch dbConfig.Driver {
+	case configs.DriverMemory:
+		return NewMemoryHotelMgmtRepo(fixture)
+	case configs.DriverSQLite:
+		//connect to embedded sqlite database
+		db, err := gorm.Open("sqlite3", configs.SQLiteURL(dbConfig))
+		if err != nil {
+			log.Fatalf("Warning: can't open sqlite database %s: %v\n", dbConfig.Path, err)
+		}
+		//sqlite allows a single writer
+		db.DB().SetMaxOpenConns(1)
+		repo := newHotelMgmtRepo(db)
+		if err = seedFixture(db, fixture); err != nil {
+			log.Fatalf("Warning: can't seed sqlite database: %v\n", err)
+		}
+		return repo
+	}
+
 	//connect to mysql database
-	db, err := gorm.Open("mysql", configs.DbURL(configs.BuildDBConfig()))
+	db, err := gorm.Open("mysql", configs.DbURL(dbConfig))
 	if err != nil {
 		fmt.Println("Status:", err)
 		fmt.Println("connection error")
 	}
+	return newHotelMgmtRepo(db)
+}
+
+func newHotelMgmtRepo(db *gorm.DB) HotelMgmtRepo {
+	isSQLite := db.Dialect().GetName() == "sqlite3"
+	if isSQLite {
+		createSQLiteTables(db)
+	}
+
 	//auto migrate table by model
 	db.AutoMigrate(&models.Hotel{}, &models.Room{}, &models.RoomType{}, &models.Price{}, &models.Order{},
 		&models.OrderStatus{}, &models.Stay{}, &models.StayRoom{}, &models.Reservation{},
-		&models.Promo{}, &models.BookingDayPromo{}, &models.StayDayPromo{})
+		&models.Promo{}, &models.BookingDayPromo{}, &models.StayDayPromo{}, &models.PromoRedemption{},
+		&models.CancellationPolicy{}, &models.OrderHistory{}, &models.RoomBlock{})
 
 	//add foreign key that next can be used for preload gorm func
-	db.Model(&models.Room{}).AddForeignKey("hotel_id", "hotels(id)", "RESTRICT", "RESTRICT")
-	db.Model(&models.Room{}).AddForeignKey("room_type_id", "room_types(id)", "RESTRICT", "RESTRICT")
-	db.Model(&models.Price{}).AddForeignKey("hotel_id", "hotels(id)", "RESTRICT", "RESTRICT")
-	db.Model(&models.Price{}).AddForeignKey("room_type_id", "room_types(id)", "RESTRICT", "RESTRICT")
+	if !isSQLite {
+		for _, fk := range foreignKeys {
+			db.Model(fk.model).AddForeignKey(fk.field, fk.dest, "RESTRICT", "RESTRICT")
+		}
+	}
+
+	//promos without code are stored with a NULL code, the unique index allows any number of them
+	db.Model(&models.Promo{}).Where("code = ''").UpdateColumn("code", gorm.Expr("NULL"))
+	//redemptions saved before customer keys keep counting against the per-customer promo limit
+	backfillCustomerKeys(db)
+
+	//a room can only be sold once per night
+	db.Model(&models.StayRoom{}).AddUniqueIndex("idx_stay_rooms_room_id_date", "room_id", "date")
+	//a room type has one price per night in a hotel
+	db.Model(&models.Price{}).AddUniqueIndex("idx_prices_hotel_id_room_type_id_date", "hotel_id", "room_type_id", "date")
+	//room numbers are unique in a hotel
+	db.Model(&models.Room{}).AddUniqueIndex("idx_rooms_hotel_id_room_number", "hotel_id", "room_number")
 	return &hotelMgmtRepo{
 		connection: db,
 	}
 }
 
-//fetching available rooms by defined fields and value
-func (repo *hotelMgmtRepo) FindAvailableRoomsByFields(unavailableRoomId []int, fields string, values ...interface{}) (rooms []*models.Room, err error) {
-	if err = repo.connection.Debug().Not(unavailableRoomId).Where(fields, values...).Find(&rooms).Error; err != nil {
+//save the customer key of redemptions without one, keys are built in go so they always match the keys of new redemptions
+func backfillCustomerKeys(db *gorm.DB) {
+	var redemptions []*models.PromoRedemption
+	if err := db.Where("customer_key = '' OR customer_key IS NULL").Find(&redemptions).Error; err != nil {
+		log.Printf("backfill promo redemption customer keys: %v", err)
+		return
+	}
+	for _, redemption := range redemptions {
+		db.Model(&models.PromoRedemption{}).Where("id = ?", redemption.ID).
+			UpdateColumn("customer_key", customerKey(redemption.CustomerName))
+	}
+}
+
+//fetching active rooms of a hotel and room type except the excluded rooms
+func (repo *hotelMgmtRepo) FindRooms(hotelID int, roomTypeID int, excludeRoomIDs []int) (rooms []*models.Room, err error) {
+	query := repo.connection.Debug().Where("hotel_id = ? AND room_type_id = ? AND deactivated_at IS NULL", hotelID, roomTypeID)
+	if len(excludeRoomIDs) > 0 {
+		query = query.Not(excludeRoomIDs)
+	}
+	if err = query.Order("id").Find(&rooms).Error; err != nil {
 		return nil, err
 	}
 	return rooms, nil
 }
 
-//fetching booked room id by defined fields and value
-func (repo *hotelMgmtRepo) FindRoomIDFromStayRoomByFields(fields string, values ...interface{}) (ids []int, err error) {
+//fetching id of rooms of a hotel booked between from date and to date
+func (repo *hotelMgmtRepo) FindBookedRoomIDs(hotelID int, fromDate string, toDate string) (ids []int, err error) {
 	var stayRooms []*models.StayRoom
-	if err = repo.connection.Debug().Where(fields, values...).Select("DISTINCT room_id").Find(&stayRooms).Error; err != nil {
+	if err = repo.connection.Debug().Joins("JOIN rooms ON rooms.id = stay_rooms.room_id").
+		Where("rooms.hotel_id = ? AND stay_rooms.date >= ? AND stay_rooms.date < ?", hotelID, fromDate, toDate).
+		Select("DISTINCT stay_rooms.room_id").Find(&stayRooms).Error; err != nil {
 		return nil, err
 	}
 	for _, stayRoom := range stayRooms {
@@ -64,14 +190,64 @@ func (repo *hotelMgmtRepo) FindRoomIDFromStayRoomByFields(fields string, values
 	return ids, nil
 }
 
-//fetching prices by defined fields and value
-func (repo *hotelMgmtRepo) FindPricesByFields(fields string, values ...interface{}) (prices []*models.Price, err error) {
-	if err = repo.connection.Debug().Where(fields, values...).Find(&prices).Error; err != nil {
+//fetching room nights sold in a hotel between from date and to date
+func (repo *hotelMgmtRepo) FindStayRooms(hotelID int, fromDate string, toDate string) (stayRooms []*models.StayRoom, err error) {
+	if err = repo.connection.Debug().Joins("JOIN rooms ON rooms.id = stay_rooms.room_id").
+		Where("rooms.hotel_id = ? AND stay_rooms.date >= ? AND stay_rooms.date < ?", hotelID, fromDate, toDate).
+		Select("stay_rooms.*").Order("stay_rooms.room_id, stay_rooms.date").Find(&stayRooms).Error; err != nil {
+		return nil, err
+	}
+	return stayRooms, nil
+}
+
+//fetching all hotels
+func (repo *hotelMgmtRepo) FindHotels() (hotels []*models.Hotel, err error) {
+	if err = repo.connection.Debug().Order("id").Find(&hotels).Error; err != nil {
+		return nil, err
+	}
+	return hotels, nil
+}
+
+//fetching hotel by id
+func (repo *hotelMgmtRepo) FindHotelByID(id int) (*models.Hotel, error) {
+	var hotel models.Hotel
+	if err := repo.connection.Debug().Where("id = ?", id).First(&hotel).Error; err != nil {
+		return nil, err
+	}
+	return &hotel, nil
+}
+
+//fetching nightly prices of a hotel and room type between from date and to date
+func (repo *hotelMgmtRepo) FindPrices(hotelID int, roomTypeID int, fromDate string, toDate string) (prices []*models.Price, err error) {
+	if err = repo.connection.Debug().Where("hotel_id = ? AND room_type_id = ? AND date >= ? AND date < ?", hotelID, roomTypeID, fromDate, toDate).
+		Order("date").Find(&prices).Error; err != nil {
 		return nil, err
 	}
 	return prices, nil
 }
 
+//fetching cancellation policy of a room type, falling back to the policy of the whole hotel, nil when none is configured
+func (repo *hotelMgmtRepo) FindCancellationPolicy(hotelID int, roomTypeID int) (*models.CancellationPolicy, error) {
+	var policies []*models.CancellationPolicy
+	if err := repo.connection.Debug().Where("hotel_id = ? AND room_type_id IN (?)", hotelID, []int{0, roomTypeID}).
+		Order("room_type_id DESC").Limit(1).Find(&policies).Error; err != nil {
+		return nil, err
+	}
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	return policies[0], nil
+}
+
+//fetching cancellation policy by id
+func (repo *hotelMgmtRepo) FindCancellationPolicyByID(id int) (*models.CancellationPolicy, error) {
+	var policy models.CancellationPolicy
+	if err := repo.connection.Debug().Where("id = ?", id).First(&policy).Error; err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
 //fetching promos by id
 func (repo *hotelMgmtRepo) FindPromoByID(id int) (*models.Promo, error) {
 	var promo models.Promo
@@ -81,6 +257,23 @@ func (repo *hotelMgmtRepo) FindPromoByID(id int) (*models.Promo, error) {
 	return &promo, nil
 }
 
+//fetching promos by code
+func (repo *hotelMgmtRepo) FindPromoByCode(code string) (*models.Promo, error) {
+	var promo models.Promo
+	if err := repo.connection.Debug().Where("code = ?", code).First(&promo).Error; err != nil {
+		return nil, err
+	}
+	return &promo, nil
+}
+
+//fetching all promos
+func (repo *hotelMgmtRepo) FindPromos() (promos []*models.Promo, err error) {
+	if err = repo.connection.Debug().Order("id").Find(&promos).Error; err != nil {
+		return nil, err
+	}
+	return promos, nil
+}
+
 //fetching stay promo by id
 func (repo *hotelMgmtRepo) FindStayPromoByID(id int) (*models.StayDayPromo, error) {
 	var promo models.StayDayPromo
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+//fetching every room of a hotel ordered by room number
+func (repo *hotelMgmtRepo) FindHotelRooms(hotelID int) (rooms []*models.Room, err error) {
+	if err = repo.connection.Debug().Where("hotel_id = ?", hotelID).Order("room_number, id").Find(&rooms).Error; err != nil {
+		return nil, err
+	}
+	return rooms, nil
+}
+
+//fetching room by id
+func (repo *hotelMgmtRepo) FindRoomByID(id int) (*models.Room, error) {
+	var room models.Room
+	if err := repo.connection.Debug().Where("id = ?", id).First(&room).Error; err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+//save housekeeping status of a room with the dates it applies to
+func (repo *hotelMgmtRepo) UpdateRoomStatus(room *models.Room) error {
+	return repo.connection.Debug().Model(&models.Room{}).Where("id = ?", room.ID).Updates(map[string]interface{}{
+		"room_status":       room.RoomStatus,
+		"status_from_date":  room.StatusFromDate,
+		"status_until_date": room.StatusUntilDate,
+	}).Error
+}
@@ -0,0 +1,1270 @@
+package repositories
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+//ErrForeignKey is returned by the memory repository when a record refers to a missing parent record
+var ErrForeignKey = errors.New("foreign key constraint failed")
+
+//memoryHotelMgmtRepo keeps every table in memory, a single mutex makes each method atomic like a transaction
+type memoryHotelMgmtRepo struct {
+	mu               sync.Mutex
+	lastID           map[string]int
+	hotels           []*models.Hotel
+	roomTypes        []*models.RoomType
+	rooms            []*models.Room
+	prices           []*models.Price
+	orderStatuses    []*models.OrderStatus
+	orders           []*models.Order
+	reservations     []*models.Reservation
+	stays            []*models.Stay
+	stayRooms        []*models.StayRoom
+	promos           []*models.Promo
+	stayDayPromos    []*models.StayDayPromo
+	bookingDayPromos []*models.BookingDayPromo
+	promoRedemptions []*models.PromoRedemption
+	policies         []*models.CancellationPolicy
+	orderHistory     []*models.OrderHistory
+	roomBlocks       []*models.RoomBlock
+}
+
+//create repository without any outside service, loaded with the fixture when given
+func NewMemoryHotelMgmtRepo(fixture *Fixture) HotelMgmtRepo {
+	repo := &memoryHotelMgmtRepo{lastID: make(map[string]int)}
+	if fixture == nil {
+		return repo
+	}
+	for _, hotel := range fixture.Hotels {
+		copied := *hotel
+		repo.hotels = append(repo.hotels, &copied)
+		repo.useID("hotels", copied.ID)
+	}
+	for _, roomType := range fixture.RoomTypes {
+		copied := *roomType
+		repo.roomTypes = append(repo.roomTypes, &copied)
+		repo.useID("room_types", copied.ID)
+	}
+	for _, room := range fixture.Rooms {
+		copied := *room
+		copied.Price = nil
+		if copied.RoomStatus == "" {
+			copied.RoomStatus = models.RoomStatusClean
+		}
+		repo.rooms = append(repo.rooms, &copied)
+		repo.useID("rooms", copied.ID)
+	}
+	for _, price := range fixture.Prices {
+		copied := *price
+		repo.prices = append(repo.prices, &copied)
+	}
+	for _, promo := range fixture.Promos {
+		copied := *promo
+		repo.promos = append(repo.promos, &copied)
+		repo.useID("promos", copied.ID)
+	}
+	for _, promo := range fixture.StayDayPromos {
+		copied := *promo
+		repo.stayDayPromos = append(repo.stayDayPromos, &copied)
+	}
+	for _, promo := range fixture.BookingDayPromos {
+		copied := *promo
+		repo.bookingDayPromos = append(repo.bookingDayPromos, &copied)
+	}
+	for _, policy := range fixture.CancellationPolicies {
+		copied := *policy
+		repo.policies = append(repo.policies, &copied)
+		repo.useID("cancellation_policies", copied.ID)
+	}
+	return repo
+}
+
+//keep auto increment after ids given by fixture
+func (repo *memoryHotelMgmtRepo) useID(table string, id int) {
+	if id > repo.lastID[table] {
+		repo.lastID[table] = id
+	}
+}
+
+//next auto increment id of table
+func (repo *memoryHotelMgmtRepo) nextID(table string) int {
+	repo.lastID[table]++
+	return repo.lastID[table]
+}
+
+func (repo *memoryHotelMgmtRepo) findHotel(id int) *models.Hotel {
+	for _, hotel := range repo.hotels {
+		if hotel.ID == id {
+			return hotel
+		}
+	}
+	return nil
+}
+
+func (repo *memoryHotelMgmtRepo) findRoom(id int) *models.Room {
+	for _, room := range repo.rooms {
+		if room.ID == id {
+			return room
+		}
+	}
+	return nil
+}
+
+func (repo *memoryHotelMgmtRepo) findPromo(id int) *models.Promo {
+	for _, promo := range repo.promos {
+		if promo.ID == id {
+			return promo
+		}
+	}
+	return nil
+}
+
+//fetching active rooms of a hotel and room type except the excluded rooms
+func (repo *memoryHotelMgmtRepo) FindRooms(hotelID int, roomTypeID int, excludeRoomIDs []int) (rooms []*models.Room, err error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	excluded := make(map[int]bool, len(excludeRoomIDs))
+	for _, id := range excludeRoomIDs {
+		excluded[id] = true
+	}
+	for _, room := range repo.rooms {
+		if room.HotelID == hotelID && room.RoomTypeID == roomTypeID && room.DeactivatedAt == nil && !excluded[room.ID] {
+			copied := *room
+			rooms = append(rooms, &copied)
+		}
+	}
+	return rooms, nil
+}
+
+//fetching every room of a hotel ordered by room number
+func (repo *memoryHotelMgmtRepo) FindHotelRooms(hotelID int) (rooms []*models.Room, err error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, room := range repo.rooms {
+		if room.HotelID == hotelID {
+			copied := *room
+			rooms = append(rooms, &copied)
+		}
+	}
+	sort.SliceStable(rooms, func(i, j int) bool { return rooms[i].RoomNumber < rooms[j].RoomNumber })
+	return rooms, nil
+}
+
+//fetching room by id
+func (repo *memoryHotelMgmtRepo) FindRoomByID(id int) (*models.Room, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	room := repo.findRoom(id)
+	if room == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *room
+	return &copied, nil
+}
+
+//save housekeeping status of a room with the dates it applies to
+func (repo *memoryHotelMgmtRepo) UpdateRoomStatus(room *models.Room) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	stored := repo.findRoom(room.ID)
+	if stored == nil {
+		return gorm.ErrRecordNotFound
+	}
+	stored.RoomStatus = room.RoomStatus
+	stored.StatusFromDate = room.StatusFromDate
+	stored.StatusUntilDate = room.StatusUntilDate
+	return nil
+}
+
+//fetching nightly prices of a hotel and room type between from date and to date
+func (repo *memoryHotelMgmtRepo) FindPrices(hotelID int, roomTypeID int, fromDate string, toDate string) (prices []*models.Price, err error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, price := range repo.prices {
+		if price.HotelID == hotelID && price.RoomTypeID == roomTypeID && price.Date >= fromDate && price.Date < toDate {
+			copied := *price
+			prices = append(prices, &copied)
+		}
+	}
+	sort.Slice(prices, func(i, j int) bool { return prices[i].Date < prices[j].Date })
+	return prices, nil
+}
+
+//fetching id of rooms of a hotel booked between from date and to date
+func (repo *memoryHotelMgmtRepo) FindBookedRoomIDs(hotelID int, fromDate string, toDate string) (ids []int, err error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	found := make(map[int]bool)
+	for _, stayRoom := range repo.stayRooms {
+		if stayRoom.Date < fromDate || stayRoom.Date >= toDate || found[stayRoom.RoomID] {
+			continue
+		}
+		if room := repo.findRoom(stayRoom.RoomID); room != nil && room.HotelID == hotelID {
+			ids = append(ids, stayRoom.RoomID)
+			found[stayRoom.RoomID] = true
+		}
+	}
+	return ids, nil
+}
+
+//fetching room nights sold in a hotel between from date and to date
+func (repo *memoryHotelMgmtRepo) FindStayRooms(hotelID int, fromDate string, toDate string) (stayRooms []*models.StayRoom, err error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, stayRoom := range repo.stayRooms {
+		if stayRoom.Date < fromDate || stayRoom.Date >= toDate {
+			continue
+		}
+		if room := repo.findRoom(stayRoom.RoomID); room != nil && room.HotelID == hotelID {
+			copied := *stayRoom
+			stayRooms = append(stayRooms, &copied)
+		}
+	}
+	sort.Slice(stayRooms, func(i, j int) bool {
+		if stayRooms[i].RoomID != stayRooms[j].RoomID {
+			return stayRooms[i].RoomID < stayRooms[j].RoomID
+		}
+		return stayRooms[i].Date < stayRooms[j].Date
+	})
+	return stayRooms, nil
+}
+
+//fetching all hotels
+func (repo *memoryHotelMgmtRepo) FindHotels() (hotels []*models.Hotel, err error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, hotel := range repo.hotels {
+		copied := *hotel
+		hotels = append(hotels, &copied)
+	}
+	return hotels, nil
+}
+
+//fetching hotel by id
+func (repo *memoryHotelMgmtRepo) FindHotelByID(id int) (*models.Hotel, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	hotel := repo.findHotel(id)
+	if hotel == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *hotel
+	return &copied, nil
+}
+
+//fetching promos by id
+func (repo *memoryHotelMgmtRepo) FindPromoByID(id int) (*models.Promo, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	promo := repo.findPromo(id)
+	if promo == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *promo
+	return &copied, nil
+}
+
+//fetching promos by code
+func (repo *memoryHotelMgmtRepo) FindPromoByCode(code string) (*models.Promo, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, promo := range repo.promos {
+		if promo.Code != nil && *promo.Code == code {
+			copied := *promo
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+//fetching all promos
+func (repo *memoryHotelMgmtRepo) FindPromos() (promos []*models.Promo, err error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, promo := range repo.promos {
+		copied := *promo
+		promos = append(promos, &copied)
+	}
+	return promos, nil
+}
+
+//fetching stay promo by id
+func (repo *memoryHotelMgmtRepo) FindStayPromoByID(id int) (*models.StayDayPromo, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, promo := range repo.stayDayPromos {
+		if promo.ID == id {
+			copied := *promo
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+//fetching booking promo by id
+func (repo *memoryHotelMgmtRepo) FindBookingPromoByID(id int) (*models.BookingDayPromo, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, promo := range repo.bookingDayPromos {
+		if promo.ID == id {
+			copied := *promo
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+//fetching cancellation policy of a room type, falling back to the policy of the whole hotel, nil when none is configured
+func (repo *memoryHotelMgmtRepo) FindCancellationPolicy(hotelID int, roomTypeID int) (*models.CancellationPolicy, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var found *models.CancellationPolicy
+	for _, policy := range repo.policies {
+		if policy.HotelID != hotelID {
+			continue
+		}
+		if policy.RoomTypeID == roomTypeID && roomTypeID != 0 {
+			found = policy
+			break
+		}
+		if policy.RoomTypeID == 0 && found == nil {
+			found = policy
+		}
+	}
+	if found == nil {
+		return nil, nil
+	}
+	copied := *found
+	return &copied, nil
+}
+
+//fetching cancellation policy by id
+func (repo *memoryHotelMgmtRepo) FindCancellationPolicyByID(id int) (*models.CancellationPolicy, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, policy := range repo.policies {
+		if policy.ID == id {
+			copied := *policy
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+//fetching reservation by id together with its order and order status
+func (repo *memoryHotelMgmtRepo) FindReservationByID(id int) (*models.Reservation, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, reservation := range repo.reservations {
+		if reservation.ID != id {
+			continue
+		}
+		copied := *reservation
+		if order := repo.findOrder(reservation.OrderID); order != nil {
+			copied.Order = *order
+			copied.Order.OrderStatus = *repo.findOrderStatus(order.OrderStatusID)
+		}
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+//fetching room nights held by a reservation
+func (repo *memoryHotelMgmtRepo) FindReservationStayRooms(reservationID int) (stayRooms []*models.StayRoom, err error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	stayIDs := repo.reservationStayIDs(reservationID)
+	for _, stayRoom := range repo.stayRooms {
+		if stayIDs[stayRoom.StayID] {
+			copied := *stayRoom
+			stayRooms = append(stayRooms, &copied)
+		}
+	}
+	sort.Slice(stayRooms, func(i, j int) bool {
+		if stayRooms[i].StayID != stayRooms[j].StayID {
+			return stayRooms[i].StayID < stayRooms[j].StayID
+		}
+		return stayRooms[i].Date < stayRooms[j].Date
+	})
+	return stayRooms, nil
+}
+
+//fetching promos redeemed by a reservation
+func (repo *memoryHotelMgmtRepo) FindPromoRedemptions(reservationID int) (redemptions []*models.PromoRedemption, err error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, redemption := range repo.promoRedemptions {
+		if redemption.ReservationID == reservationID {
+			copied := *redemption
+			redemptions = append(redemptions, &copied)
+		}
+	}
+	return redemptions, nil
+}
+
+func (repo *memoryHotelMgmtRepo) reservationStayIDs(reservationID int) map[int]bool {
+	stayIDs := make(map[int]bool)
+	for _, stay := range repo.stays {
+		if stay.ReservationID == reservationID {
+			stayIDs[stay.ID] = true
+		}
+	}
+	return stayIDs
+}
+
+func (repo *memoryHotelMgmtRepo) findReservation(id int) *models.Reservation {
+	for _, reservation := range repo.reservations {
+		if reservation.ID == id {
+			return reservation
+		}
+	}
+	return nil
+}
+
+func (repo *memoryHotelMgmtRepo) findOrder(id int) *models.Order {
+	for _, order := range repo.orders {
+		if order.ID == id {
+			return order
+		}
+	}
+	return nil
+}
+
+func (repo *memoryHotelMgmtRepo) findOrderStatus(id int) *models.OrderStatus {
+	for _, orderStatus := range repo.orderStatuses {
+		if orderStatus.ID == id {
+			return orderStatus
+		}
+	}
+	return &models.OrderStatus{}
+}
+
+//resolve order status from lookup table, adding it when missing
+func (repo *memoryHotelMgmtRepo) orderStatus(status string) *models.OrderStatus {
+	for _, existing := range repo.orderStatuses {
+		if existing.Status == status {
+			return existing
+		}
+	}
+	orderStatus := &models.OrderStatus{ID: repo.nextID("order_statuses"), Status: status}
+	repo.orderStatuses = append(repo.orderStatuses, orderStatus)
+	return orderStatus
+}
+
+//create order, reservation, stays and per-night stay rooms, every check runs before any write so a failure leaves nothing behind
+func (repo *memoryHotelMgmtRepo) CreateReservation(reservation *models.Reservation, status string, roomIDs []int, dates []string) error {
+	return repo.CreateReservations([]*models.Reservation{reservation}, status, [][]int{roomIDs}, dates)
+}
+
+//create reservations of the same stay dates, roomIDs[i] are the rooms of reservations[i],
+//every check of every reservation runs before any write so all of them are saved or none
+func (repo *memoryHotelMgmtRepo) CreateReservations(reservations []*models.Reservation, status string, roomIDs [][]int, dates []string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	taken := make(map[int]map[string]bool)
+	for _, stayRoom := range repo.stayRooms {
+		if taken[stayRoom.RoomID] == nil {
+			taken[stayRoom.RoomID] = make(map[string]bool)
+		}
+		taken[stayRoom.RoomID][stayRoom.Date] = true
+	}
+	//redemptions of the reservations checked before in the batch count against the promo limits like stored ones
+	var batch []*models.PromoRedemption
+	for i, reservation := range reservations {
+		if repo.findHotel(reservation.HotelID) == nil {
+			return ErrForeignKey
+		}
+		for _, roomID := range roomIDs[i] {
+			if repo.findRoom(roomID) == nil {
+				return ErrForeignKey
+			}
+			if taken[roomID] == nil {
+				taken[roomID] = make(map[string]bool)
+			}
+			for _, date := range dates {
+				if taken[roomID][date] {
+					return ErrRoomUnavailable
+				}
+				taken[roomID][date] = true
+			}
+		}
+		for _, promoID := range reservation.PromoIDs {
+			if err := repo.checkPromoRedemption(promoID, reservation.CustomerName, batch); err != nil {
+				return err
+			}
+			batch = append(batch, &models.PromoRedemption{PromoID: promoID, CustomerKey: customerKey(reservation.CustomerName)})
+		}
+	}
+
+	orderStatus := repo.orderStatus(status)
+	for i, reservation := range reservations {
+		repo.addReservation(reservation, orderStatus, roomIDs[i], dates)
+	}
+	return nil
+}
+
+//save order, reservation, stays and per-night stay rooms of a reservation which passed every check
+func (repo *memoryHotelMgmtRepo) addReservation(reservation *models.Reservation, orderStatus *models.OrderStatus, roomIDs []int, dates []string) {
+	reservation.Order.ID = repo.nextID("orders")
+	reservation.Order.OrderStatusID = orderStatus.ID
+	reservation.Order.OrderStatus = *orderStatus
+	order := reservation.Order
+	repo.orders = append(repo.orders, &order)
+	repo.addOrderHistory(order.ID, "", orderStatus.Status)
+
+	reservation.ID = repo.nextID("reservations")
+	reservation.OrderID = order.ID
+	stored := *reservation
+	stored.Order = models.Order{}
+	repo.reservations = append(repo.reservations, &stored)
+
+	for _, promoID := range reservation.PromoIDs {
+		repo.findPromo(promoID).RedemptionCount++
+		repo.promoRedemptions = append(repo.promoRedemptions, &models.PromoRedemption{
+			ID:            repo.nextID("promo_redemptions"),
+			PromoID:       promoID,
+			ReservationID: reservation.ID,
+			CustomerName:  reservation.CustomerName,
+			CustomerKey:   customerKey(reservation.CustomerName),
+			CreatedAt:     time.Now(),
+		})
+	}
+
+	//one stay for each booked room and one stay room for each night
+	for _, roomID := range roomIDs {
+		stay := &models.Stay{
+			ID:            repo.nextID("stays"),
+			ReservationID: reservation.ID,
+			GuestName:     reservation.CustomerName,
+			RoomID:        roomID,
+		}
+		repo.stays = append(repo.stays, stay)
+		for _, date := range dates {
+			repo.stayRooms = append(repo.stayRooms, &models.StayRoom{
+				ID:     repo.nextID("stay_rooms"),
+				StayID: stay.ID,
+				RoomID: roomID,
+				Date:   date,
+			})
+		}
+	}
+}
+
+//release and hold room nights of a modified reservation, drop promos it no longer qualifies for and save its new price,
+//every check runs before any write so a failure leaves nothing behind
+func (repo *memoryHotelMgmtRepo) UpdateReservation(reservation *models.Reservation, releaseNights []*models.StayRoom, holdNights []*models.StayRoom, removedPromoIDs []int) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	stored := repo.findReservation(reservation.ID)
+	if stored == nil {
+		return gorm.ErrRecordNotFound
+	}
+	order := repo.findOrder(stored.OrderID)
+	if order == nil {
+		return gorm.ErrRecordNotFound
+	}
+	if order.OrderStatusID != reservation.Order.OrderStatusID {
+		return ErrOrderStatusChanged
+	}
+	released := make(map[int]bool, len(releaseNights))
+	for _, night := range releaseNights {
+		released[night.ID] = true
+	}
+	taken := make(map[int]map[string]bool)
+	for _, stayRoom := range repo.stayRooms {
+		if released[stayRoom.ID] {
+			continue
+		}
+		if taken[stayRoom.RoomID] == nil {
+			taken[stayRoom.RoomID] = make(map[string]bool)
+		}
+		taken[stayRoom.RoomID][stayRoom.Date] = true
+	}
+	for _, night := range holdNights {
+		if repo.findRoom(night.RoomID) == nil {
+			return ErrForeignKey
+		}
+		if taken[night.RoomID][night.Date] {
+			return ErrRoomUnavailable
+		}
+	}
+
+	stayRooms := repo.stayRooms[:0]
+	for _, stayRoom := range repo.stayRooms {
+		if !released[stayRoom.ID] {
+			stayRooms = append(stayRooms, stayRoom)
+		}
+	}
+	repo.stayRooms = stayRooms
+
+	newStays := make(map[int]int)
+	for _, night := range holdNights {
+		if night.StayID == 0 {
+			if _, ok := newStays[night.RoomID]; !ok {
+				stay := &models.Stay{
+					ID:            repo.nextID("stays"),
+					ReservationID: reservation.ID,
+					GuestName:     reservation.CustomerName,
+					RoomID:        night.RoomID,
+				}
+				repo.stays = append(repo.stays, stay)
+				newStays[night.RoomID] = stay.ID
+			}
+			night.StayID = newStays[night.RoomID]
+		}
+		night.ID = repo.nextID("stay_rooms")
+		copied := *night
+		repo.stayRooms = append(repo.stayRooms, &copied)
+	}
+
+	//rooms without any night left are no longer part of the reservation
+	nights := make(map[int]bool)
+	for _, stayRoom := range repo.stayRooms {
+		nights[stayRoom.StayID] = true
+	}
+	stays := repo.stays[:0]
+	for _, stay := range repo.stays {
+		if stay.ReservationID != reservation.ID || nights[stay.ID] {
+			stays = append(stays, stay)
+		}
+	}
+	repo.stays = stays
+
+	for _, promoID := range removedPromoIDs {
+		repo.releasePromo(reservation.ID, promoID)
+	}
+
+	stored.BookedRoomCount = reservation.BookedRoomCount
+	stored.RoomTypeID = reservation.RoomTypeID
+	stored.CheckinDate = reservation.CheckinDate
+	stored.CheckoutDate = reservation.CheckoutDate
+	order.FinalPrice = reservation.Order.FinalPrice
+	order.FirstNightPrice = reservation.Order.FirstNightPrice
+	return nil
+}
+
+//move order to the given status with the cancellation fee, release every night held by the reservation and give back its promo redemptions
+func (repo *memoryHotelMgmtRepo) CancelReservation(reservation *models.Reservation, status string, cancellationFee int) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	order, err := repo.setOrderStatus(&reservation.Order, status)
+	if err != nil {
+		return err
+	}
+	order.CancellationFee = cancellationFee
+
+	stayIDs := repo.reservationStayIDs(reservation.ID)
+	stayRooms := repo.stayRooms[:0]
+	for _, stayRoom := range repo.stayRooms {
+		if !stayIDs[stayRoom.StayID] {
+			stayRooms = append(stayRooms, stayRoom)
+		}
+	}
+	repo.stayRooms = stayRooms
+
+	var promoIDs []int
+	for _, redemption := range repo.promoRedemptions {
+		if redemption.ReservationID == reservation.ID {
+			promoIDs = append(promoIDs, redemption.PromoID)
+		}
+	}
+	for _, promoID := range promoIDs {
+		repo.releasePromo(reservation.ID, promoID)
+	}
+
+	reservation.Order = *order
+	return nil
+}
+
+//give back the redemption of a promo by a reservation so it counts again against the promo limits
+func (repo *memoryHotelMgmtRepo) releasePromo(reservationID int, promoID int) {
+	redemptions := repo.promoRedemptions[:0]
+	for _, redemption := range repo.promoRedemptions {
+		if redemption.ReservationID != reservationID || redemption.PromoID != promoID {
+			redemptions = append(redemptions, redemption)
+		}
+	}
+	repo.promoRedemptions = redemptions
+	if promo := repo.findPromo(promoID); promo != nil && promo.RedemptionCount > 0 {
+		promo.RedemptionCount--
+	}
+}
+
+//check promo limits before counting a redemption, batch holds the redemptions of the same call not saved yet
+func (repo *memoryHotelMgmtRepo) checkPromoRedemption(promoID int, customerName string, batch []*models.PromoRedemption) error {
+	promo := repo.findPromo(promoID)
+	if promo == nil {
+		return gorm.ErrRecordNotFound
+	}
+	redeemed, used := promo.RedemptionCount, 0
+	for _, redemption := range batch {
+		if redemption.PromoID == promoID {
+			redeemed++
+			if redemption.CustomerKey == customerKey(customerName) {
+				used++
+			}
+		}
+	}
+	if promo.MaxRedemptions > 0 && redeemed >= promo.MaxRedemptions {
+		return ErrPromoFullyRedeemed
+	}
+	if promo.MaxRedemptionsPerCustomer > 0 {
+		for _, redemption := range repo.promoRedemptions {
+			if redemption.PromoID == promoID && redemption.CustomerKey == customerKey(customerName) {
+				used++
+			}
+		}
+		if used >= promo.MaxRedemptionsPerCustomer {
+			return ErrPromoCustomerLimit
+		}
+	}
+	return nil
+}
+
+//fetching order by id together with its order status
+func (repo *memoryHotelMgmtRepo) FindOrderByID(id int) (*models.Order, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	order := repo.findOrder(id)
+	if order == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *order
+	copied.OrderStatus = *repo.findOrderStatus(order.OrderStatusID)
+	return &copied, nil
+}
+
+//fetching status changes of an order, oldest first
+func (repo *memoryHotelMgmtRepo) FindOrderHistory(orderID int) (history []*models.OrderHistory, err error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, change := range repo.orderHistory {
+		if change.OrderID == orderID {
+			copied := *change
+			history = append(history, &copied)
+		}
+	}
+	return history, nil
+}
+
+//move order to the given status and record the transition, the nights of a no-show are released
+func (repo *memoryHotelMgmtRepo) UpdateOrderStatus(order *models.Order, status string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, err := repo.setOrderStatus(order, status); err != nil {
+		return err
+	}
+	//guests who never arrive can't use the rooms any more, their nights go back on sale
+	if status == models.OrderStatusNoShow {
+		stayIDs := make(map[int]bool)
+		for _, reservation := range repo.reservations {
+			if reservation.OrderID == order.ID {
+				for stayID := range repo.reservationStayIDs(reservation.ID) {
+					stayIDs[stayID] = true
+				}
+			}
+		}
+		stayRooms := repo.stayRooms[:0]
+		for _, stayRoom := range repo.stayRooms {
+			if !stayIDs[stayRoom.StayID] {
+				stayRooms = append(stayRooms, stayRoom)
+			}
+		}
+		repo.stayRooms = stayRooms
+	}
+	return nil
+}
+
+//change order status only when it is still the status the order was read with
+func (repo *memoryHotelMgmtRepo) setOrderStatus(order *models.Order, status string) (*models.Order, error) {
+	stored := repo.findOrder(order.ID)
+	if stored == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if stored.OrderStatusID != order.OrderStatusID {
+		return nil, ErrOrderStatusChanged
+	}
+	orderStatus := repo.orderStatus(status)
+	stored.OrderStatusID = orderStatus.ID
+	stored.OrderStatus = *orderStatus
+	repo.addOrderHistory(order.ID, order.OrderStatus.Status, status)
+
+	order.OrderStatusID = orderStatus.ID
+	order.OrderStatus = *orderStatus
+	return stored, nil
+}
+
+func (repo *memoryHotelMgmtRepo) addOrderHistory(orderID int, fromStatus string, toStatus string) {
+	repo.orderHistory = append(repo.orderHistory, &models.OrderHistory{
+		ID:         repo.nextID("order_histories"),
+		OrderID:    orderID,
+		FromStatus: fromStatus,
+		ToStatus:   toStatus,
+		CreatedAt:  time.Now(),
+	})
+}
+
+//fetching stays of a reservation together with their rooms
+func (repo *memoryHotelMgmtRepo) FindReservationStays(reservationID int) (stays []*models.Stay, err error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, stay := range repo.stays {
+		if stay.ReservationID != reservationID {
+			continue
+		}
+		copied := *stay
+		if room := repo.findRoom(stay.RoomID); room != nil {
+			copied.Room = *room
+		}
+		stays = append(stays, &copied)
+	}
+	return stays, nil
+}
+
+//save guest names and arrival time of the stays and move order to checked-in
+func (repo *memoryHotelMgmtRepo) CheckInReservation(reservation *models.Reservation, stays []*models.Stay) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, err := repo.setOrderStatus(&reservation.Order, models.OrderStatusCheckedIn); err != nil {
+		return err
+	}
+	for _, stay := range stays {
+		if stored := repo.findStay(stay.ID); stored != nil && stored.ReservationID == reservation.ID {
+			stored.GuestName = stay.GuestName
+			stored.CheckedInAt = stay.CheckedInAt
+		}
+	}
+	return nil
+}
+
+//close the stays, flag their rooms dirty, release nights from the given date and move order to checked-out
+func (repo *memoryHotelMgmtRepo) CheckOutReservation(reservation *models.Reservation, stays []*models.Stay, releaseFromDate string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, err := repo.setOrderStatus(&reservation.Order, models.OrderStatusCheckedOut); err != nil {
+		return err
+	}
+	for _, stay := range stays {
+		if stored := repo.findStay(stay.ID); stored != nil && stored.ReservationID == reservation.ID {
+			stored.CheckedOutAt = stay.CheckedOutAt
+		}
+		//rooms taken out of order keep their status
+		if room := repo.findRoom(stay.RoomID); room != nil &&
+			room.RoomStatus != models.RoomStatusOutOfOrder && room.RoomStatus != models.RoomStatusOutOfService {
+			room.RoomStatus = models.RoomStatusDirty
+		}
+	}
+
+	stayIDs := repo.reservationStayIDs(reservation.ID)
+	stayRooms := repo.stayRooms[:0]
+	for _, stayRoom := range repo.stayRooms {
+		if !stayIDs[stayRoom.StayID] || stayRoom.Date < releaseFromDate {
+			stayRooms = append(stayRooms, stayRoom)
+		}
+	}
+	repo.stayRooms = stayRooms
+	return nil
+}
+
+func (repo *memoryHotelMgmtRepo) findStay(id int) *models.Stay {
+	for _, stay := range repo.stays {
+		if stay.ID == id {
+			return stay
+		}
+	}
+	return nil
+}
+
+//fetching blocks of the rooms of a hotel overlapping from date until to date
+func (repo *memoryHotelMgmtRepo) FindRoomBlocks(hotelID int, fromDate string, toDate string) (blocks []*models.RoomBlock, err error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, block := range repo.roomBlocks {
+		if block.FromDate >= toDate || block.UntilDate <= fromDate {
+			continue
+		}
+		if room := repo.findRoom(block.RoomID); room != nil && room.HotelID == hotelID {
+			copied := *block
+			blocks = append(blocks, &copied)
+		}
+	}
+	sort.SliceStable(blocks, func(i, j int) bool { return blocks[i].FromDate < blocks[j].FromDate })
+	return blocks, nil
+}
+
+//fetching room block by id
+func (repo *memoryHotelMgmtRepo) FindRoomBlockByID(id int) (*models.RoomBlock, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	block := repo.findRoomBlock(id)
+	if block == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *block
+	return &copied, nil
+}
+
+//create room block
+func (repo *memoryHotelMgmtRepo) CreateRoomBlock(block *models.RoomBlock) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if repo.findRoom(block.RoomID) == nil {
+		return ErrForeignKey
+	}
+	block.ID = repo.nextID("room_blocks")
+	block.CreatedAt = time.Now()
+	copied := *block
+	repo.roomBlocks = append(repo.roomBlocks, &copied)
+	return nil
+}
+
+//update dates and reason of a room block
+func (repo *memoryHotelMgmtRepo) UpdateRoomBlock(block *models.RoomBlock) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	stored := repo.findRoomBlock(block.ID)
+	if stored == nil {
+		return gorm.ErrRecordNotFound
+	}
+	if repo.findRoom(block.RoomID) == nil {
+		return ErrForeignKey
+	}
+	stored.RoomID = block.RoomID
+	stored.FromDate = block.FromDate
+	stored.UntilDate = block.UntilDate
+	stored.Reason = block.Reason
+	return nil
+}
+
+//delete room block
+func (repo *memoryHotelMgmtRepo) DeleteRoomBlock(id int) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for i, block := range repo.roomBlocks {
+		if block.ID == id {
+			repo.roomBlocks = append(repo.roomBlocks[:i], repo.roomBlocks[i+1:]...)
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (repo *memoryHotelMgmtRepo) findRoomBlock(id int) *models.RoomBlock {
+	for _, block := range repo.roomBlocks {
+		if block.ID == id {
+			return block
+		}
+	}
+	return nil
+}
+
+//fetching all room types
+func (repo *memoryHotelMgmtRepo) FindRoomTypes() (roomTypes []*models.RoomType, err error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, roomType := range repo.roomTypes {
+		copied := *roomType
+		roomTypes = append(roomTypes, &copied)
+	}
+	return roomTypes, nil
+}
+
+//fetching room type by id
+func (repo *memoryHotelMgmtRepo) FindRoomTypeByID(id int) (*models.RoomType, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	roomType := repo.findRoomType(id)
+	if roomType == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *roomType
+	return &copied, nil
+}
+
+//fetching every room of a room type in all hotels
+func (repo *memoryHotelMgmtRepo) FindRoomTypeRooms(roomTypeID int) (rooms []*models.Room, err error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, room := range repo.rooms {
+		if room.RoomTypeID == roomTypeID {
+			copied := *room
+			rooms = append(rooms, &copied)
+		}
+	}
+	sort.SliceStable(rooms, func(i, j int) bool {
+		if rooms[i].HotelID != rooms[j].HotelID {
+			return rooms[i].HotelID < rooms[j].HotelID
+		}
+		return rooms[i].RoomNumber < rooms[j].RoomNumber
+	})
+	return rooms, nil
+}
+
+//create hotel
+func (repo *memoryHotelMgmtRepo) CreateHotel(hotel *models.Hotel) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	hotel.ID = repo.nextID("hotels")
+	if hotel.Timezone == "" {
+		hotel.Timezone = "UTC"
+	}
+	copied := *hotel
+	repo.hotels = append(repo.hotels, &copied)
+	return nil
+}
+
+//update name, address, timezone, stay rules and deactivation time of a hotel
+func (repo *memoryHotelMgmtRepo) UpdateHotel(hotel *models.Hotel) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	stored := repo.findHotel(hotel.ID)
+	if stored == nil {
+		return gorm.ErrRecordNotFound
+	}
+	stored.HotelName = hotel.HotelName
+	stored.Address = hotel.Address
+	stored.Timezone = hotel.Timezone
+	stored.MinStayNights = hotel.MinStayNights
+	stored.MaxStayNights = hotel.MaxStayNights
+	stored.BookingHorizon = hotel.BookingHorizon
+	stored.DeactivatedAt = hotel.DeactivatedAt
+	return nil
+}
+
+//create room type
+func (repo *memoryHotelMgmtRepo) CreateRoomType(roomType *models.RoomType) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	roomType.ID = repo.nextID("room_types")
+	copied := *roomType
+	repo.roomTypes = append(repo.roomTypes, &copied)
+	return nil
+}
+
+//update name, base rate and deactivation time of a room type
+func (repo *memoryHotelMgmtRepo) UpdateRoomType(roomType *models.RoomType) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	stored := repo.findRoomType(roomType.ID)
+	if stored == nil {
+		return gorm.ErrRecordNotFound
+	}
+	stored.Name = roomType.Name
+	stored.BaseRate = roomType.BaseRate
+	stored.DeactivatedAt = roomType.DeactivatedAt
+	return nil
+}
+
+//create room, the room number must be free in the hotel
+func (repo *memoryHotelMgmtRepo) CreateRoom(room *models.Room) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if err := repo.checkRoom(room); err != nil {
+		return err
+	}
+	room.ID = repo.nextID("rooms")
+	if room.RoomStatus == "" {
+		room.RoomStatus = models.RoomStatusClean
+	}
+	copied := *room
+	copied.Price = nil
+	repo.rooms = append(repo.rooms, &copied)
+	return nil
+}
+
+//update hotel, room type, room number and deactivation time of a room, the room number must be free in the hotel
+func (repo *memoryHotelMgmtRepo) UpdateRoom(room *models.Room) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	stored := repo.findRoom(room.ID)
+	if stored == nil {
+		return gorm.ErrRecordNotFound
+	}
+	if err := repo.checkRoom(room); err != nil {
+		return err
+	}
+	stored.HotelID = room.HotelID
+	stored.RoomTypeID = room.RoomTypeID
+	stored.RoomNumber = room.RoomNumber
+	stored.DeactivatedAt = room.DeactivatedAt
+	return nil
+}
+
+//check hotel and room type of a room exist and no other room of the hotel has the room number
+func (repo *memoryHotelMgmtRepo) checkRoom(room *models.Room) error {
+	if repo.findHotel(room.HotelID) == nil || repo.findRoomType(room.RoomTypeID) == nil {
+		return ErrForeignKey
+	}
+	for _, other := range repo.rooms {
+		if other.HotelID == room.HotelID && other.RoomNumber == room.RoomNumber && other.ID != room.ID {
+			return ErrRoomNumberTaken
+		}
+	}
+	return nil
+}
+
+func (repo *memoryHotelMgmtRepo) findRoomType(id int) *models.RoomType {
+	for _, roomType := range repo.roomTypes {
+		if roomType.ID == id {
+			return roomType
+		}
+	}
+	return nil
+}
+
+//replace nightly prices of a hotel and room type on the dates of the given prices, all of them or none are saved
+func (repo *memoryHotelMgmtRepo) SavePrices(hotelID int, roomTypeID int, prices []*models.Price) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if repo.findHotel(hotelID) == nil || repo.findRoomType(roomTypeID) == nil {
+		return ErrForeignKey
+	}
+	replaced := make(map[string]bool, len(prices))
+	for _, price := range prices {
+		replaced[price.Date] = true
+	}
+	kept := repo.prices[:0]
+	for _, price := range repo.prices {
+		if price.HotelID != hotelID || price.RoomTypeID != roomTypeID || !replaced[price.Date] {
+			kept = append(kept, price)
+		}
+	}
+	repo.prices = kept
+	for _, price := range prices {
+		price.HotelID = hotelID
+		price.RoomTypeID = roomTypeID
+		copied := *price
+		repo.prices = append(repo.prices, &copied)
+	}
+	return nil
+}
+
+//counting active rooms of a hotel and room type
+func (repo *memoryHotelMgmtRepo) CountRooms(hotelID int, roomTypeID int) (count int, err error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, room := range repo.rooms {
+		if room.HotelID == hotelID && room.RoomTypeID == roomTypeID && room.DeactivatedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+//counting rooms of a hotel and room type sold on each date between from date and to date, dates without sold rooms are left out
+func (repo *memoryHotelMgmtRepo) CountSoldRooms(hotelID int, roomTypeID int, fromDate string, toDate string) (counts []*models.DateCount, err error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	sold := make(map[string]map[int]bool)
+	for _, stayRoom := range repo.stayRooms {
+		if stayRoom.Date < fromDate || stayRoom.Date >= toDate {
+			continue
+		}
+		if room := repo.findRoom(stayRoom.RoomID); room != nil && room.HotelID == hotelID && room.RoomTypeID == roomTypeID {
+			if sold[stayRoom.Date] == nil {
+				sold[stayRoom.Date] = make(map[int]bool)
+			}
+			sold[stayRoom.Date][stayRoom.RoomID] = true
+		}
+	}
+	for date, rooms := range sold {
+		counts = append(counts, &models.DateCount{Date: date, Count: len(rooms)})
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i].Date < counts[j].Date })
+	return counts, nil
+}
+
+//fetching block dates of every blocked room of a hotel and room type, only blocks overlapping from date until to date are fetched
+func (repo *memoryHotelMgmtRepo) FindBlockedRoomDates(hotelID int, roomTypeID int, fromDate string, toDate string) (ranges []*models.RoomDateRange, err error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, block := range repo.roomBlocks {
+		if block.FromDate >= toDate || block.UntilDate <= fromDate {
+			continue
+		}
+		if room := repo.findRoom(block.RoomID); room != nil && room.HotelID == hotelID && room.RoomTypeID == roomTypeID {
+			ranges = append(ranges, &models.RoomDateRange{RoomID: block.RoomID, FromDate: block.FromDate, UntilDate: block.UntilDate})
+		}
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		if ranges[i].FromDate != ranges[j].FromDate {
+			return ranges[i].FromDate < ranges[j].FromDate
+		}
+		return ranges[i].RoomID < ranges[j].RoomID
+	})
+	return ranges, nil
+}
+
+//fetching status dates of every active room of a hotel and room type having the housekeeping status, open status dates are empty
+func (repo *memoryHotelMgmtRepo) FindRoomStatusDates(hotelID int, roomTypeID int, status string) (ranges []*models.RoomDateRange, err error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, room := range repo.rooms {
+		if room.HotelID != hotelID || room.RoomTypeID != roomTypeID || room.DeactivatedAt != nil || room.RoomStatus != status {
+			continue
+		}
+		dates := &models.RoomDateRange{RoomID: room.ID}
+		if room.StatusFromDate != nil {
+			dates.FromDate = room.StatusFromDate.Format("2006-01-02")
+		}
+		if room.StatusUntilDate != nil {
+			dates.UntilDate = room.StatusUntilDate.Format("2006-01-02")
+		}
+		ranges = append(ranges, dates)
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].RoomID < ranges[j].RoomID })
+	return ranges, nil
+}
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+//replace nightly prices of a hotel and room type on the dates of the given prices, all of them or none are saved
+func (repo *hotelMgmtRepo) SavePrices(hotelID int, roomTypeID int, prices []*models.Price) (err error) {
+	tx := repo.connection.Debug().Begin()
+	if err = tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	dates := make([]string, 0, len(prices))
+	for _, price := range prices {
+		dates = append(dates, price.Date)
+	}
+	if err = tx.Where("hotel_id = ? AND room_type_id = ? AND date IN (?)", hotelID, roomTypeID, dates).
+		Delete(&models.Price{}).Error; err != nil {
+		return err
+	}
+	for _, price := range prices {
+		price.HotelID = hotelID
+		price.RoomTypeID = roomTypeID
+		if err = tx.Create(price).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
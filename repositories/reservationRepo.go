@@ -0,0 +1,391 @@
+package repositories
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jinzhu/gorm"
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+var (
+	//ErrNotFound is returned by every backend when a record doesn't exist
+	ErrNotFound = gorm.ErrRecordNotFound
+	//ErrRoomUnavailable is returned when one of the requested room nights has been sold by another booking
+	ErrRoomUnavailable = errors.New("room has just been booked by another reservation, please search again")
+	//ErrPromoFullyRedeemed is returned when the promo has reached its redemption limit
+	ErrPromoFullyRedeemed = errors.New("sorry, this promo has been fully redeemed")
+	//ErrPromoCustomerLimit is returned when the customer has used the promo as many times as allowed
+	ErrPromoCustomerLimit = errors.New("sorry, you have reached the usage limit of this promo")
+	//ErrOrderStatusChanged is returned when another request changed the order status first
+	ErrOrderStatusChanged = errors.New("order status has just been changed by another request, please try again")
+)
+
+//create order, reservation, stays and per-night stay rooms in a single transaction
+func (repo *hotelMgmtRepo) CreateReservation(reservation *models.Reservation, status string, roomIDs []int, dates []string) error {
+	return repo.CreateReservations([]*models.Reservation{reservation}, status, [][]int{roomIDs}, dates)
+}
+
+//create reservations of the same stay dates in a single transaction, roomIDs[i] are the rooms of reservations[i],
+//every reservation is saved or none of them
+func (repo *hotelMgmtRepo) CreateReservations(reservations []*models.Reservation, status string, roomIDs [][]int, dates []string) (err error) {
+	tx := repo.connection.Debug().Set("gorm:save_associations", false).Begin()
+	if err = tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	//resolve order status from lookup table
+	var orderStatus models.OrderStatus
+	if err = tx.Where(models.OrderStatus{Status: status}).FirstOrCreate(&orderStatus).Error; err != nil {
+		return err
+	}
+	for i, reservation := range reservations {
+		if err = createReservation(tx, reservation, orderStatus, roomIDs[i], dates); err != nil {
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+//create order, reservation, stays and per-night stay rooms of one reservation inside the transaction
+func createReservation(tx *gorm.DB, reservation *models.Reservation, orderStatus models.OrderStatus, roomIDs []int, dates []string) (err error) {
+	reservation.Order.OrderStatusID = orderStatus.ID
+	reservation.Order.OrderStatus = orderStatus
+	if err = tx.Create(&reservation.Order).Error; err != nil {
+		return err
+	}
+	if err = tx.Create(&models.OrderHistory{OrderID: reservation.Order.ID, ToStatus: orderStatus.Status}).Error; err != nil {
+		return err
+	}
+
+	reservation.OrderID = reservation.Order.ID
+	if err = tx.Create(reservation).Error; err != nil {
+		return err
+	}
+
+	for _, promoID := range reservation.PromoIDs {
+		if err = redeemPromo(tx, reservation, promoID); err != nil {
+			return err
+		}
+	}
+
+	//one stay for each booked room and one stay room for each night
+	for _, roomID := range roomIDs {
+		stay := models.Stay{
+			ReservationID: reservation.ID,
+			GuestName:     reservation.CustomerName,
+			RoomID:        roomID,
+		}
+		if err = tx.Create(&stay).Error; err != nil {
+			return err
+		}
+		for _, date := range dates {
+			stayRoom := models.StayRoom{
+				StayID: stay.ID,
+				RoomID: roomID,
+				Date:   date,
+			}
+			if err = tx.Create(&stayRoom).Error; err != nil {
+				if isUniqueViolation(err) {
+					err = ErrRoomUnavailable
+				}
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+//fetching reservation by id together with its order and order status
+func (repo *hotelMgmtRepo) FindReservationByID(id int) (*models.Reservation, error) {
+	var reservation models.Reservation
+	if err := repo.connection.Debug().Preload("Order").Preload("Order.OrderStatus").
+		Where("id = ?", id).First(&reservation).Error; err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+//fetching room nights held by a reservation
+func (repo *hotelMgmtRepo) FindReservationStayRooms(reservationID int) (stayRooms []*models.StayRoom, err error) {
+	if err = repo.connection.Debug().Joins("JOIN stays ON stays.id = stay_rooms.stay_id").
+		Where("stays.reservation_id = ?", reservationID).
+		Select("stay_rooms.*").Order("stay_rooms.stay_id, stay_rooms.date").Find(&stayRooms).Error; err != nil {
+		return nil, err
+	}
+	return stayRooms, nil
+}
+
+//fetching promos redeemed by a reservation
+func (repo *hotelMgmtRepo) FindPromoRedemptions(reservationID int) (redemptions []*models.PromoRedemption, err error) {
+	if err = repo.connection.Debug().Where("reservation_id = ?", reservationID).Order("id").Find(&redemptions).Error; err != nil {
+		return nil, err
+	}
+	return redemptions, nil
+}
+
+//release and hold room nights of a modified reservation, drop promos it no longer qualifies for and save its new price in a single transaction,
+//hold nights without stay id get a new stay for their room, the order must still have the status the reservation was read with
+func (repo *hotelMgmtRepo) UpdateReservation(reservation *models.Reservation, releaseNights []*models.StayRoom, holdNights []*models.StayRoom, removedPromoIDs []int) (err error) {
+	tx := repo.connection.Debug().Set("gorm:save_associations", false).Begin()
+	if err = tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	//the conditional update locks the order row so a concurrent cancellation or check-in waits for the modification or fails it
+	update := tx.Model(&models.Order{}).Where("id = ? AND order_status_id = ?", reservation.OrderID, reservation.Order.OrderStatusID).
+		UpdateColumns(map[string]interface{}{
+			"final_price":       reservation.Order.FinalPrice,
+			"first_night_price": reservation.Order.FirstNightPrice,
+		})
+	if err = update.Error; err != nil {
+		return err
+	}
+	if update.RowsAffected == 0 {
+		return ErrOrderStatusChanged
+	}
+
+	if len(releaseNights) > 0 {
+		ids := make([]int, 0, len(releaseNights))
+		for _, night := range releaseNights {
+			ids = append(ids, night.ID)
+		}
+		if err = tx.Where("id IN (?)", ids).Delete(&models.StayRoom{}).Error; err != nil {
+			return err
+		}
+	}
+
+	newStays := make(map[int]int)
+	for _, night := range holdNights {
+		if night.StayID == 0 {
+			if _, ok := newStays[night.RoomID]; !ok {
+				stay := models.Stay{
+					ReservationID: reservation.ID,
+					GuestName:     reservation.CustomerName,
+					RoomID:        night.RoomID,
+				}
+				if err = tx.Create(&stay).Error; err != nil {
+					return err
+				}
+				newStays[night.RoomID] = stay.ID
+			}
+			night.StayID = newStays[night.RoomID]
+		}
+		if err = tx.Create(night).Error; err != nil {
+			if isUniqueViolation(err) {
+				err = ErrRoomUnavailable
+			}
+			return err
+		}
+	}
+
+	//rooms without any night left are no longer part of the reservation
+	if err = tx.Where("reservation_id = ? AND id NOT IN (SELECT stay_id FROM stay_rooms)", reservation.ID).
+		Delete(&models.Stay{}).Error; err != nil {
+		return err
+	}
+
+	for _, promoID := range removedPromoIDs {
+		if err = releasePromo(tx, reservation.ID, promoID); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Model(&models.Reservation{}).Where("id = ?", reservation.ID).Updates(map[string]interface{}{
+		"booked_room_count": reservation.BookedRoomCount,
+		"room_type_id":      reservation.RoomTypeID,
+		"checkin_date":      reservation.CheckinDate,
+		"checkout_date":     reservation.CheckoutDate,
+	}).Error; err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+//move order to the given status with the cancellation fee, release every night held by the reservation and give back its promo redemptions
+func (repo *hotelMgmtRepo) CancelReservation(reservation *models.Reservation, status string, cancellationFee int) (err error) {
+	tx := repo.connection.Debug().Set("gorm:save_associations", false).Begin()
+	if err = tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = setOrderStatus(tx, &reservation.Order, status); err != nil {
+		return err
+	}
+	if err = tx.Model(&models.Order{}).Where("id = ?", reservation.OrderID).
+		UpdateColumn("cancellation_fee", cancellationFee).Error; err != nil {
+		return err
+	}
+	if err = tx.Where("stay_id IN (SELECT id FROM stays WHERE reservation_id = ?)", reservation.ID).
+		Delete(&models.StayRoom{}).Error; err != nil {
+		return err
+	}
+	var redemptions []*models.PromoRedemption
+	if err = tx.Where("reservation_id = ?", reservation.ID).Find(&redemptions).Error; err != nil {
+		return err
+	}
+	for _, redemption := range redemptions {
+		if err = releasePromo(tx, reservation.ID, redemption.PromoID); err != nil {
+			return err
+		}
+	}
+	if err = tx.Commit().Error; err != nil {
+		return err
+	}
+
+	reservation.Order.CancellationFee = cancellationFee
+	return nil
+}
+
+//fetching order by id together with its order status
+func (repo *hotelMgmtRepo) FindOrderByID(id int) (*models.Order, error) {
+	var order models.Order
+	if err := repo.connection.Debug().Preload("OrderStatus").Where("id = ?", id).First(&order).Error; err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+//fetching status changes of an order, oldest first
+func (repo *hotelMgmtRepo) FindOrderHistory(orderID int) (history []*models.OrderHistory, err error) {
+	if err = repo.connection.Debug().Where("order_id = ?", orderID).Order("id").Find(&history).Error; err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+//move order to the given status and record the transition, the nights of a no-show are released
+func (repo *hotelMgmtRepo) UpdateOrderStatus(order *models.Order, status string) (err error) {
+	tx := repo.connection.Debug().Set("gorm:save_associations", false).Begin()
+	if err = tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = setOrderStatus(tx, order, status); err != nil {
+		return err
+	}
+	//guests who never arrive can't use the rooms any more, their nights go back on sale
+	if status == models.OrderStatusNoShow {
+		if err = tx.Where("stay_id IN (SELECT stays.id FROM stays JOIN reservations ON reservations.id = stays.reservation_id WHERE reservations.order_id = ?)", order.ID).
+			Delete(&models.StayRoom{}).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+//change order status only when it is still the status the order was read with, so concurrent transitions can't both win
+func setOrderStatus(tx *gorm.DB, order *models.Order, status string) error {
+	var orderStatus models.OrderStatus
+	if err := tx.Where(models.OrderStatus{Status: status}).FirstOrCreate(&orderStatus).Error; err != nil {
+		return err
+	}
+	update := tx.Model(&models.Order{}).Where("id = ? AND order_status_id = ?", order.ID, order.OrderStatusID).
+		UpdateColumn("order_status_id", orderStatus.ID)
+	if update.Error != nil {
+		return update.Error
+	}
+	if update.RowsAffected == 0 {
+		return ErrOrderStatusChanged
+	}
+	if err := tx.Create(&models.OrderHistory{
+		OrderID:    order.ID,
+		FromStatus: order.OrderStatus.Status,
+		ToStatus:   status,
+	}).Error; err != nil {
+		return err
+	}
+
+	order.OrderStatusID = orderStatus.ID
+	order.OrderStatus = orderStatus
+	return nil
+}
+
+//give back the redemption of a promo by a reservation so it counts again against the promo limits
+func releasePromo(tx *gorm.DB, reservationID int, promoID int) error {
+	if err := tx.Where("reservation_id = ? AND promo_id = ?", reservationID, promoID).
+		Delete(&models.PromoRedemption{}).Error; err != nil {
+		return err
+	}
+	return tx.Model(&models.Promo{}).Where("id = ? AND redemption_count > 0", promoID).
+		UpdateColumn("redemption_count", gorm.Expr("redemption_count - 1")).Error
+}
+
+//count promo redemption, the conditional update locks the promo row so concurrent bookings of the promo run one after another,
+//the per-customer count is a locking read on mysql so it sees redemptions committed after the transaction snapshot was taken
+func redeemPromo(tx *gorm.DB, reservation *models.Reservation, promoID int) error {
+	update := tx.Model(&models.Promo{}).
+		Where("id = ? AND (max_redemptions = 0 OR redemption_count < max_redemptions)", promoID).
+		UpdateColumn("redemption_count", gorm.Expr("redemption_count + 1"))
+	if update.Error != nil {
+		return update.Error
+	}
+	if update.RowsAffected == 0 {
+		return ErrPromoFullyRedeemed
+	}
+
+	var promo models.Promo
+	if err := tx.Where("id = ?", promoID).First(&promo).Error; err != nil {
+		return err
+	}
+	key := customerKey(reservation.CustomerName)
+	if promo.MaxRedemptionsPerCustomer > 0 {
+		//sqlite has a single writer and no FOR UPDATE, the promo row update already serialises the bookings
+		query := tx
+		if tx.Dialect().GetName() == "mysql" {
+			query = tx.Set("gorm:query_option", "FOR UPDATE")
+		}
+		var used int
+		if err := query.Model(&models.PromoRedemption{}).
+			Where("promo_id = ? AND customer_key = ?", promo.ID, key).
+			Count(&used).Error; err != nil {
+			return err
+		}
+		if used >= promo.MaxRedemptionsPerCustomer {
+			return ErrPromoCustomerLimit
+		}
+	}
+
+	return tx.Create(&models.PromoRedemption{
+		PromoID:       promo.ID,
+		ReservationID: reservation.ID,
+		CustomerName:  reservation.CustomerName,
+		CustomerKey:   key,
+	}).Error
+}
+
+//customer name in lower case with single spaces, the per-customer promo limit can't be bypassed by spelling the name differently
+func customerKey(customerName string) string {
+	return strings.ToLower(strings.Join(strings.Fields(customerName), " "))
+}
+
+//check duplicate key error of mysql and sqlite
+func isUniqueViolation(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1062
+	}
+	return strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
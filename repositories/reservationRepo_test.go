@@ -0,0 +1,660 @@
+package repositories
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/nurcholisnanda/hotel-management-system/configs"
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+func newTestFixture() *Fixture {
+	return &Fixture{
+		Hotels:    []*models.Hotel{{ID: 1, HotelName: "Test Hotel"}},
+		RoomTypes: []*models.RoomType{{ID: 1, Name: "Double"}},
+		Rooms: []*models.Room{
+			{ID: 1, HotelID: 1, RoomTypeID: 1, RoomNumber: 101},
+			{ID: 2, HotelID: 1, RoomTypeID: 1, RoomNumber: 102},
+			{ID: 3, HotelID: 1, RoomTypeID: 1, RoomNumber: 103},
+		},
+		Promos: []*models.Promo{
+			{ID: 1, Code: promoCode("LONGSTAY"), MaxRedemptions: 2, MaxRedemptionsPerCustomer: 1},
+		},
+	}
+}
+
+func newSQLiteTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := openSQLiteTestDB(t, "")
+	db.DB().SetMaxOpenConns(1)
+	return db
+}
+
+//open a sqlite database file of the test, options are appended to the connection url
+func openSQLiteTestDB(t *testing.T, options string) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open("sqlite3", configs.SQLiteURL(&configs.DBConfig{Path: filepath.Join(t.TempDir(), "hms.db")})+options)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	db.LogMode(false)
+	return db
+}
+
+//run test against every repository backend loaded with the same fixture
+func forEachBackend(t *testing.T, fixture *Fixture, test func(t *testing.T, repo HotelMgmtRepo)) {
+	t.Run("sqlite", func(t *testing.T) {
+		db := newSQLiteTestDB(t)
+		repo := newHotelMgmtRepo(db)
+		if err := seedFixture(db, fixture); err != nil {
+			t.Fatalf("seed sqlite: %v", err)
+		}
+		test(t, repo)
+	})
+	t.Run("memory", func(t *testing.T) {
+		test(t, NewMemoryHotelMgmtRepo(fixture))
+	})
+}
+
+func newTestReservation(customer string, checkinDate string, checkoutDate string, promoIDs ...int) *models.Reservation {
+	return &models.Reservation{
+		CustomerName:    customer,
+		BookedRoomCount: 1,
+		CheckinDate:     checkinDate,
+		CheckoutDate:    checkoutDate,
+		HotelID:         1,
+		PromoIDs:        promoIDs,
+	}
+}
+
+func TestCreateReservationConcurrentSingleRoom(t *testing.T) {
+	const attempts = 20
+	test := func(t *testing.T, repo HotelMgmtRepo) {
+		dates := []string{"2022-12-01", "2022-12-02"}
+
+		var wg sync.WaitGroup
+		errs := make(chan error, attempts)
+		wg.Add(attempts)
+		for i := 0; i < attempts; i++ {
+			go func() {
+				defer wg.Done()
+				reservation := newTestReservation("guest", "2022-12-01", "2022-12-03")
+				errs <- repo.CreateReservation(reservation, models.OrderStatusPending, []int{1}, dates)
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		succeeded := 0
+		for err := range errs {
+			switch {
+			case err == nil:
+				succeeded++
+			case errors.Is(err, ErrRoomUnavailable):
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}
+		if succeeded != 1 {
+			t.Fatalf("expected exactly one successful booking, got %d", succeeded)
+		}
+
+		ids, err := repo.FindBookedRoomIDs(1, "2022-12-01", "2022-12-03")
+		if err != nil || len(ids) != 1 || ids[0] != 1 {
+			t.Fatalf("expected only room 1 booked, got %v (%v)", ids, err)
+		}
+	}
+
+	//every booking gets its own connection so they race on the database instead of queueing for a single connection,
+	//transactions take the write lock on begin and wait for each other, only the unique stay room index can refuse the room
+	t.Run("sqlite", func(t *testing.T) {
+		db := openSQLiteTestDB(t, "&_journal_mode=WAL&_txlock=immediate")
+		db.DB().SetMaxOpenConns(attempts)
+		repo := newHotelMgmtRepo(db)
+		if err := seedFixture(db, newTestFixture()); err != nil {
+			t.Fatalf("seed sqlite: %v", err)
+		}
+		test(t, repo)
+	})
+	t.Run("memory", func(t *testing.T) {
+		test(t, NewMemoryHotelMgmtRepo(newTestFixture()))
+	})
+}
+
+func TestCreateReservationPromoLimits(t *testing.T) {
+	forEachBackend(t, newTestFixture(), func(t *testing.T, repo HotelMgmtRepo) {
+		book := func(customer string, roomID int) error {
+			reservation := newTestReservation(customer, "2022-12-01", "2022-12-02", 1)
+			return repo.CreateReservation(reservation, models.OrderStatusPending, []int{roomID}, []string{"2022-12-01"})
+		}
+
+		if err := book("alice", 1); err != nil {
+			t.Fatalf("first redemption: %v", err)
+		}
+		for _, spelling := range []string{"alice", " Alice ", "ALICE"} {
+			if err := book(spelling, 2); !errors.Is(err, ErrPromoCustomerLimit) {
+				t.Fatalf("%q: expected customer limit error, got %v", spelling, err)
+			}
+		}
+		if err := book("bob", 2); err != nil {
+			t.Fatalf("second redemption: %v", err)
+		}
+		if err := book("carol", 3); !errors.Is(err, ErrPromoFullyRedeemed) {
+			t.Fatalf("expected fully redeemed error, got %v", err)
+		}
+
+		promo, err := repo.FindPromoByID(1)
+		if err != nil || promo.RedemptionCount != 2 {
+			t.Fatalf("expected 2 redemptions, got %+v (%v)", promo, err)
+		}
+		ids, _ := repo.FindBookedRoomIDs(1, "2022-12-01", "2022-12-02")
+		if len(ids) != 2 {
+			t.Fatalf("rejected bookings left stay rooms behind: %v", ids)
+		}
+	})
+}
+
+func TestForeignKeys(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) {
+		db := newSQLiteTestDB(t)
+		newHotelMgmtRepo(db)
+		if err := db.Create(&models.Room{HotelID: 99, RoomTypeID: 99, RoomNumber: 101}).Error; err == nil {
+			t.Fatal("expected room with missing hotel to be rejected")
+		}
+	})
+	t.Run("memory", func(t *testing.T) {
+		repo := NewMemoryHotelMgmtRepo(newTestFixture())
+		reservation := newTestReservation("guest", "2022-12-01", "2022-12-02")
+		if err := repo.CreateReservation(reservation, models.OrderStatusPending, []int{99}, []string{"2022-12-01"}); !errors.Is(err, ErrForeignKey) {
+			t.Fatalf("expected foreign key error, got %v", err)
+		}
+	})
+}
+
+func TestCancelReservationReleasesNights(t *testing.T) {
+	fixture := newTestFixture()
+	fixture.CancellationPolicies = []*models.CancellationPolicy{
+		{ID: 1, HotelID: 1, FreeCancellationDays: 3, Penalty: models.PenaltyFirstNight},
+		{ID: 2, HotelID: 1, RoomTypeID: 1, NonRefundable: true},
+	}
+	forEachBackend(t, fixture, func(t *testing.T, repo HotelMgmtRepo) {
+		if policy, err := repo.FindCancellationPolicy(1, 1); err != nil || policy.ID != 2 {
+			t.Fatalf("expected room type policy, got %+v (%v)", policy, err)
+		}
+		if policy, err := repo.FindCancellationPolicy(1, 2); err != nil || policy.ID != 1 {
+			t.Fatalf("expected hotel policy, got %+v (%v)", policy, err)
+		}
+
+		dates := []string{"2022-12-01", "2022-12-02"}
+		reservation := newTestReservation("guest", "2022-12-01", "2022-12-03")
+		if err := repo.CreateReservation(reservation, models.OrderStatusPending, []int{1}, dates); err != nil {
+			t.Fatalf("create reservation: %v", err)
+		}
+		found, err := repo.FindReservationByID(reservation.ID)
+		if err != nil || found.Order.OrderStatus.Status != models.OrderStatusPending {
+			t.Fatalf("expected pending reservation, got %+v (%v)", found, err)
+		}
+		if err = repo.CancelReservation(found, models.OrderStatusCancelled, 50000); err != nil {
+			t.Fatalf("cancel reservation: %v", err)
+		}
+
+		found, err = repo.FindReservationByID(reservation.ID)
+		if err != nil || found.Order.OrderStatus.Status != models.OrderStatusCancelled || found.Order.CancellationFee != 50000 {
+			t.Fatalf("expected cancelled order with fee, got %+v (%v)", found.Order, err)
+		}
+		if ids, _ := repo.FindBookedRoomIDs(1, "2022-12-01", "2022-12-03"); len(ids) != 0 {
+			t.Fatalf("cancelled reservation still holds rooms %v", ids)
+		}
+		if err = repo.CreateReservation(newTestReservation("other", "2022-12-01", "2022-12-03"), models.OrderStatusPending, []int{1}, dates); err != nil {
+			t.Fatalf("released room can't be booked again: %v", err)
+		}
+	})
+}
+
+func TestCancelReservationReleasesPromo(t *testing.T) {
+	forEachBackend(t, newTestFixture(), func(t *testing.T, repo HotelMgmtRepo) {
+		dates := []string{"2022-12-01"}
+		reservation := newTestReservation("alice", "2022-12-01", "2022-12-02", 1)
+		if err := repo.CreateReservation(reservation, models.OrderStatusPending, []int{1}, dates); err != nil {
+			t.Fatalf("create reservation: %v", err)
+		}
+		found, _ := repo.FindReservationByID(reservation.ID)
+		if err := repo.CancelReservation(found, models.OrderStatusCancelled, 0); err != nil {
+			t.Fatalf("cancel reservation: %v", err)
+		}
+		if redemptions, _ := repo.FindPromoRedemptions(reservation.ID); len(redemptions) != 0 {
+			t.Fatalf("cancelled reservation still redeems %v", redemptions)
+		}
+
+		//the guest rebooks with the promo limited to one redemption per customer
+		rebooked := newTestReservation("alice", "2022-12-01", "2022-12-02", 1)
+		if err := repo.CreateReservation(rebooked, models.OrderStatusPending, []int{1}, dates); err != nil {
+			t.Fatalf("rebook with promo: %v", err)
+		}
+		if promo, err := repo.FindPromoByID(1); err != nil || promo.RedemptionCount != 1 {
+			t.Fatalf("expected 1 redemption, got %+v (%v)", promo, err)
+		}
+	})
+}
+
+func TestUpdateReservationMovesNights(t *testing.T) {
+	forEachBackend(t, newTestFixture(), func(t *testing.T, repo HotelMgmtRepo) {
+		reservation := newTestReservation("guest", "2022-12-01", "2022-12-03", 1)
+		if err := repo.CreateReservation(reservation, models.OrderStatusPending, []int{1}, []string{"2022-12-01", "2022-12-02"}); err != nil {
+			t.Fatalf("create reservation: %v", err)
+		}
+		other := newTestReservation("other", "2022-12-03", "2022-12-04")
+		if err := repo.CreateReservation(other, models.OrderStatusPending, []int{3}, []string{"2022-12-03"}); err != nil {
+			t.Fatalf("create other reservation: %v", err)
+		}
+		held, err := repo.FindReservationStayRooms(reservation.ID)
+		if err != nil || len(held) != 2 {
+			t.Fatalf("expected 2 held nights, got %v (%v)", held, err)
+		}
+
+		//shift the stay by one night and add room 2
+		reservation.CheckinDate, reservation.CheckoutDate, reservation.BookedRoomCount = "2022-12-02", "2022-12-04", 2
+		reservation.Order.FinalPrice = 400000
+		hold := []*models.StayRoom{
+			{StayID: held[0].StayID, RoomID: 1, Date: "2022-12-03"},
+			{RoomID: 2, Date: "2022-12-02"},
+			{RoomID: 2, Date: "2022-12-03"},
+		}
+		if err = repo.UpdateReservation(reservation, held[:1], hold, []int{1}); err != nil {
+			t.Fatalf("update reservation: %v", err)
+		}
+
+		nights, _ := repo.FindReservationStayRooms(reservation.ID)
+		if len(nights) != 4 {
+			t.Fatalf("expected 4 held nights, got %d", len(nights))
+		}
+		if ids, _ := repo.FindBookedRoomIDs(1, "2022-12-01", "2022-12-02"); len(ids) != 0 {
+			t.Fatalf("released night still booked by %v", ids)
+		}
+		found, err := repo.FindReservationByID(reservation.ID)
+		if err != nil || found.BookedRoomCount != 2 || found.CheckinDate[0:10] != "2022-12-02" || found.Order.FinalPrice != 400000 {
+			t.Fatalf("reservation not updated: %+v (%v)", found, err)
+		}
+		if redemptions, _ := repo.FindPromoRedemptions(reservation.ID); len(redemptions) != 0 {
+			t.Fatalf("removed promo still redeemed: %v", redemptions)
+		}
+		if promo, _ := repo.FindPromoByID(1); promo.RedemptionCount != 0 {
+			t.Fatalf("expected promo redemption to be returned, got %d", promo.RedemptionCount)
+		}
+
+		//room 3 is sold to the other reservation on the new night
+		conflict := []*models.StayRoom{{RoomID: 3, Date: "2022-12-03"}}
+		if err = repo.UpdateReservation(reservation, nil, conflict, nil); !errors.Is(err, ErrRoomUnavailable) {
+			t.Fatalf("expected room unavailable, got %v", err)
+		}
+
+		//the order was cancelled after the reservation was read, the modification must not hold any night
+		order, _ := repo.FindOrderByID(reservation.OrderID)
+		if err = repo.UpdateOrderStatus(order, models.OrderStatusCancelled); err != nil {
+			t.Fatalf("cancel order: %v", err)
+		}
+		late := []*models.StayRoom{{RoomID: 2, Date: "2022-12-04"}}
+		if err = repo.UpdateReservation(reservation, nil, late, nil); !errors.Is(err, ErrOrderStatusChanged) {
+			t.Fatalf("expected order status changed, got %v", err)
+		}
+		if ids, _ := repo.FindBookedRoomIDs(1, "2022-12-04", "2022-12-05"); len(ids) != 0 {
+			t.Fatalf("night held for a cancelled order by %v", ids)
+		}
+	})
+}
+
+func TestNoShowReleasesNights(t *testing.T) {
+	forEachBackend(t, newTestFixture(), func(t *testing.T, repo HotelMgmtRepo) {
+		dates := []string{"2022-12-01", "2022-12-02"}
+		reservation := newTestReservation("guest", "2022-12-01", "2022-12-03")
+		if err := repo.CreateReservation(reservation, models.OrderStatusConfirmed, []int{1}, dates); err != nil {
+			t.Fatalf("create reservation: %v", err)
+		}
+		order, _ := repo.FindOrderByID(reservation.OrderID)
+		if err := repo.UpdateOrderStatus(order, models.OrderStatusNoShow); err != nil {
+			t.Fatalf("mark no-show: %v", err)
+		}
+
+		if ids, _ := repo.FindBookedRoomIDs(1, "2022-12-01", "2022-12-03"); len(ids) != 0 {
+			t.Fatalf("no-show still holds rooms %v", ids)
+		}
+		if err := repo.CreateReservation(newTestReservation("other", "2022-12-01", "2022-12-03"), models.OrderStatusPending, []int{1}, dates); err != nil {
+			t.Fatalf("room of the no-show can't be booked again: %v", err)
+		}
+	})
+}
+
+func TestUpdateOrderStatusRecordsHistory(t *testing.T) {
+	forEachBackend(t, newTestFixture(), func(t *testing.T, repo HotelMgmtRepo) {
+		reservation := newTestReservation("guest", "2022-12-01", "2022-12-02")
+		if err := repo.CreateReservation(reservation, models.OrderStatusPending, []int{1}, []string{"2022-12-01"}); err != nil {
+			t.Fatalf("create reservation: %v", err)
+		}
+		order, err := repo.FindOrderByID(reservation.OrderID)
+		if err != nil {
+			t.Fatalf("find order: %v", err)
+		}
+		stale := *order
+		if err = repo.UpdateOrderStatus(order, models.OrderStatusConfirmed); err != nil {
+			t.Fatalf("confirm order: %v", err)
+		}
+		if err = repo.UpdateOrderStatus(&stale, models.OrderStatusCancelled); !errors.Is(err, ErrOrderStatusChanged) {
+			t.Fatalf("expected stale status to be rejected, got %v", err)
+		}
+
+		history, err := repo.FindOrderHistory(order.ID)
+		if err != nil || len(history) != 2 {
+			t.Fatalf("expected 2 status changes, got %d (%v)", len(history), err)
+		}
+		if history[0].ToStatus != models.OrderStatusPending || history[1].FromStatus != models.OrderStatusPending ||
+			history[1].ToStatus != models.OrderStatusConfirmed || history[1].CreatedAt.IsZero() {
+			t.Fatalf("unexpected history %+v %+v", history[0], history[1])
+		}
+		if found, _ := repo.FindOrderByID(order.ID); found.OrderStatus.Status != models.OrderStatusConfirmed {
+			t.Fatalf("expected confirmed order, got %q", found.OrderStatus.Status)
+		}
+	})
+}
+
+func TestCheckOutReservationReleasesRemainingNights(t *testing.T) {
+	forEachBackend(t, newTestFixture(), func(t *testing.T, repo HotelMgmtRepo) {
+		reservation := newTestReservation("guest", "2022-12-01", "2022-12-04")
+		dates := []string{"2022-12-01", "2022-12-02", "2022-12-03"}
+		if err := repo.CreateReservation(reservation, models.OrderStatusConfirmed, []int{1, 2}, dates); err != nil {
+			t.Fatalf("create reservation: %v", err)
+		}
+		stays, err := repo.FindReservationStays(reservation.ID)
+		if err != nil || len(stays) != 2 || stays[0].Room.RoomNumber != 101 {
+			t.Fatalf("expected stays with rooms, got %v (%v)", stays, err)
+		}
+
+		arrival := time.Date(2022, 12, 1, 14, 0, 0, 0, time.UTC)
+		stays[1].GuestName = "friend"
+		for _, stay := range stays {
+			stay.CheckedInAt = &arrival
+		}
+		if err = repo.CheckInReservation(reservation, stays); err != nil {
+			t.Fatalf("check in: %v", err)
+		}
+		departure := arrival.AddDate(0, 0, 1)
+		for _, stay := range stays {
+			stay.CheckedOutAt = &departure
+		}
+		if err = repo.CheckOutReservation(reservation, stays, "2022-12-02"); err != nil {
+			t.Fatalf("check out: %v", err)
+		}
+
+		stays, _ = repo.FindReservationStays(reservation.ID)
+		if stays[1].GuestName != "friend" || stays[0].CheckedInAt == nil || stays[0].CheckedOutAt == nil || stays[0].Room.RoomStatus != models.RoomStatusDirty {
+			t.Fatalf("stay not closed: %+v", stays[0])
+		}
+		if nights, _ := repo.FindReservationStayRooms(reservation.ID); len(nights) != 2 {
+			t.Fatalf("expected only the first night of both rooms to be kept, got %d nights", len(nights))
+		}
+		if found, _ := repo.FindOrderByID(reservation.OrderID); found.OrderStatus.Status != models.OrderStatusCheckedOut {
+			t.Fatalf("expected checked-out order, got %q", found.OrderStatus.Status)
+		}
+	})
+}
+
+func TestUpdateRoomStatus(t *testing.T) {
+	forEachBackend(t, newTestFixture(), func(t *testing.T, repo HotelMgmtRepo) {
+		room, err := repo.FindRoomByID(2)
+		if err != nil || room.RoomStatus != models.RoomStatusClean {
+			t.Fatalf("expected clean room, got %+v (%v)", room, err)
+		}
+		from := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+		room.RoomStatus, room.StatusFromDate = models.RoomStatusOutOfOrder, &from
+		if err = repo.UpdateRoomStatus(room); err != nil {
+			t.Fatalf("update room status: %v", err)
+		}
+
+		rooms, err := repo.FindHotelRooms(1)
+		if err != nil || len(rooms) != 3 {
+			t.Fatalf("expected 3 rooms, got %d (%v)", len(rooms), err)
+		}
+		if rooms[1].RoomStatus != models.RoomStatusOutOfOrder || rooms[1].StatusFromDate == nil ||
+			rooms[1].StatusFromDate.Format("2006-01-02") != "2022-12-01" || rooms[1].StatusUntilDate != nil {
+			t.Fatalf("room status not saved: %+v", rooms[1])
+		}
+	})
+}
+
+func TestRoomBlocks(t *testing.T) {
+	forEachBackend(t, newTestFixture(), func(t *testing.T, repo HotelMgmtRepo) {
+		block := &models.RoomBlock{RoomID: 2, FromDate: "2022-12-01", UntilDate: "2022-12-04", Reason: "plumbing"}
+		if err := repo.CreateRoomBlock(block); err != nil || block.ID == 0 {
+			t.Fatalf("create room block: %v", err)
+		}
+		if err := repo.CreateRoomBlock(&models.RoomBlock{RoomID: 99, FromDate: "2022-12-01", UntilDate: "2022-12-02"}); err == nil {
+			t.Fatal("expected room block of unknown room to fail")
+		}
+
+		//until date is the first night the room can be sold again
+		if blocks, _ := repo.FindRoomBlocks(1, "2022-12-04", "2022-12-06"); len(blocks) != 0 {
+			t.Fatalf("expected no block after until date, got %d", len(blocks))
+		}
+		if blocks, _ := repo.FindRoomBlocks(1, "2022-12-03", "2022-12-04"); len(blocks) != 1 || blocks[0].Reason != "plumbing" {
+			t.Fatalf("expected the block on its last night, got %+v", blocks)
+		}
+
+		block.UntilDate, block.Reason = "2022-12-06", "repainting"
+		if err := repo.UpdateRoomBlock(block); err != nil {
+			t.Fatalf("update room block: %v", err)
+		}
+		found, err := repo.FindRoomBlockByID(block.ID)
+		if err != nil || found.UntilDate[0:10] != "2022-12-06" || found.Reason != "repainting" {
+			t.Fatalf("room block not updated: %+v (%v)", found, err)
+		}
+
+		if err = repo.DeleteRoomBlock(block.ID); err != nil {
+			t.Fatalf("delete room block: %v", err)
+		}
+		if _, err = repo.FindRoomBlockByID(block.ID); err == nil {
+			t.Fatal("expected deleted room block to be gone")
+		}
+		if err = repo.DeleteRoomBlock(block.ID); err == nil {
+			t.Fatal("expected deleting a missing room block to fail")
+		}
+	})
+}
+
+func TestCreateAndUpdateRoom(t *testing.T) {
+	forEachBackend(t, newTestFixture(), func(t *testing.T, repo HotelMgmtRepo) {
+		roomType := &models.RoomType{Name: "Suite"}
+		if err := repo.CreateRoomType(roomType); err != nil || roomType.ID == 0 {
+			t.Fatalf("create room type: %v", err)
+		}
+		room := &models.Room{HotelID: 1, RoomTypeID: roomType.ID, RoomNumber: 104}
+		if err := repo.CreateRoom(room); err != nil || room.ID == 0 {
+			t.Fatalf("create room: %v", err)
+		}
+		if err := repo.CreateRoom(&models.Room{HotelID: 1, RoomTypeID: 1, RoomNumber: 101}); !errors.Is(err, ErrRoomNumberTaken) {
+			t.Fatalf("expected room number 101 to be taken, got %v", err)
+		}
+		room.RoomNumber = 102
+		if err := repo.UpdateRoom(room); !errors.Is(err, ErrRoomNumberTaken) {
+			t.Fatalf("expected room number 102 to be taken, got %v", err)
+		}
+
+		//deactivated rooms are never sold
+		deactivatedAt := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+		room.RoomNumber, room.DeactivatedAt = 104, &deactivatedAt
+		if err := repo.UpdateRoom(room); err != nil {
+			t.Fatalf("deactivate room: %v", err)
+		}
+		if rooms, _ := repo.FindRooms(1, roomType.ID, nil); len(rooms) != 0 {
+			t.Fatalf("expected no sellable suite, got %d", len(rooms))
+		}
+		rooms, err := repo.FindRoomTypeRooms(roomType.ID)
+		if err != nil || len(rooms) != 1 || rooms[0].DeactivatedAt == nil || rooms[0].RoomStatus != models.RoomStatusClean {
+			t.Fatalf("expected one deactivated clean suite, got %+v (%v)", rooms, err)
+		}
+	})
+}
+
+func TestSavePricesReplacesDates(t *testing.T) {
+	forEachBackend(t, newTestFixture(), func(t *testing.T, repo HotelMgmtRepo) {
+		if err := repo.SavePrices(1, 1, []*models.Price{{Date: "2022-12-01", Price: 100}, {Date: "2022-12-02", Price: 100}}); err != nil {
+			t.Fatalf("save prices: %v", err)
+		}
+		if err := repo.SavePrices(1, 1, []*models.Price{{Date: "2022-12-02", Price: 200}, {Date: "2022-12-03", Price: 200}}); err != nil {
+			t.Fatalf("save prices again: %v", err)
+		}
+		if err := repo.SavePrices(1, 99, []*models.Price{{Date: "2022-12-04", Price: 300}}); err == nil {
+			t.Fatal("expected prices of unknown room type to fail")
+		}
+
+		prices, err := repo.FindPrices(1, 1, "2022-12-01", "2022-12-05")
+		if err != nil || len(prices) != 3 {
+			t.Fatalf("expected 3 prices, got %d (%v)", len(prices), err)
+		}
+		for i, want := range []int{100, 200, 200} {
+			if prices[i].Price != want {
+				t.Fatalf("night %s: expected price %d, got %d", prices[i].Date, want, prices[i].Price)
+			}
+		}
+	})
+}
+
+func TestCountRoomsPerDate(t *testing.T) {
+	forEachBackend(t, newTestFixture(), func(t *testing.T, repo HotelMgmtRepo) {
+		reservation := newTestReservation("guest", "2022-12-01", "2022-12-03")
+		reservation.BookedRoomCount = 2
+		if err := repo.CreateReservation(reservation, models.OrderStatusPending, []int{1, 2}, []string{"2022-12-01", "2022-12-02"}); err != nil {
+			t.Fatalf("create reservation: %v", err)
+		}
+		for _, block := range []*models.RoomBlock{
+			{RoomID: 3, FromDate: "2022-12-02", UntilDate: "2022-12-05"},
+			{RoomID: 1, FromDate: "2022-12-03", UntilDate: "2022-12-05"},
+		} {
+			if err := repo.CreateRoomBlock(block); err != nil {
+				t.Fatalf("create room block: %v", err)
+			}
+		}
+
+		if count, err := repo.CountRooms(1, 1); err != nil || count != 3 {
+			t.Fatalf("expected 3 rooms, got %d (%v)", count, err)
+		}
+		sold, err := repo.CountSoldRooms(1, 1, "2022-12-02", "2022-12-04")
+		if err != nil || len(sold) != 1 || sold[0].Date[0:10] != "2022-12-02" || sold[0].Count != 2 {
+			t.Fatalf("expected 2 rooms sold on 2022-12-02 only, got %+v (%v)", sold, err)
+		}
+		if sold, _ = repo.CountSoldRooms(1, 2, "2022-12-01", "2022-12-04"); len(sold) != 0 {
+			t.Fatalf("expected no sold rooms of another room type, got %+v", sold)
+		}
+		blocked, err := repo.FindBlockedRoomDates(1, 1, "2022-12-04", "2022-12-10")
+		if err != nil || len(blocked) != 2 || blocked[0].RoomID != 3 || blocked[0].FromDate[0:10] != "2022-12-02" || blocked[1].RoomID != 1 {
+			t.Fatalf("expected both blocks, got %+v (%v)", blocked, err)
+		}
+		if blocked, _ = repo.FindBlockedRoomDates(1, 1, "2022-12-05", "2022-12-10"); len(blocked) != 0 {
+			t.Fatalf("expected no block after until date, got %+v", blocked)
+		}
+
+		room, _ := repo.FindRoomByID(2)
+		from, until := time.Date(2022, 12, 2, 0, 0, 0, 0, time.UTC), time.Date(2022, 12, 4, 0, 0, 0, 0, time.UTC)
+		room.RoomStatus, room.StatusFromDate, room.StatusUntilDate = models.RoomStatusOutOfOrder, &from, &until
+		if err = repo.UpdateRoomStatus(room); err != nil {
+			t.Fatalf("update room status: %v", err)
+		}
+		outOfOrder, err := repo.FindRoomStatusDates(1, 1, models.RoomStatusOutOfOrder)
+		if err != nil || len(outOfOrder) != 1 || outOfOrder[0].RoomID != 2 || outOfOrder[0].FromDate[0:10] != "2022-12-02" || outOfOrder[0].UntilDate[0:10] != "2022-12-04" {
+			t.Fatalf("expected room 2 out of order from 2022-12-02 until 2022-12-04, got %+v (%v)", outOfOrder, err)
+		}
+	})
+}
+
+func TestCreateReservationsAllOrNone(t *testing.T) {
+	forEachBackend(t, newTestFixture(), func(t *testing.T, repo HotelMgmtRepo) {
+		dates := []string{"2022-12-01", "2022-12-02"}
+		if err := repo.CreateReservation(newTestReservation("first", "2022-12-01", "2022-12-03"), models.OrderStatusPending, []int{1}, dates); err != nil {
+			t.Fatalf("create reservation: %v", err)
+		}
+
+		//room 1 of the second reservation is taken, the first one must not be kept either
+		reservations := []*models.Reservation{newTestReservation("family", "2022-12-01", "2022-12-03"), newTestReservation("family", "2022-12-01", "2022-12-03")}
+		if err := repo.CreateReservations(reservations, models.OrderStatusPending, [][]int{{2}, {1}}, dates); !errors.Is(err, ErrRoomUnavailable) {
+			t.Fatalf("expected room unavailable, got %v", err)
+		}
+		if ids, _ := repo.FindBookedRoomIDs(1, "2022-12-01", "2022-12-03"); len(ids) != 1 || ids[0] != 1 {
+			t.Fatalf("expected only room 1 booked, got %v", ids)
+		}
+
+		reservations = []*models.Reservation{newTestReservation("family", "2022-12-01", "2022-12-03"), newTestReservation("family", "2022-12-01", "2022-12-03")}
+		if err := repo.CreateReservations(reservations, models.OrderStatusPending, [][]int{{2}, {3}}, dates); err != nil {
+			t.Fatalf("create reservations: %v", err)
+		}
+		for _, reservation := range reservations {
+			stayRooms, err := repo.FindReservationStayRooms(reservation.ID)
+			if err != nil || reservation.OrderID == 0 || reservation.Order.OrderStatus.Status != models.OrderStatusPending || len(stayRooms) != 2 {
+				t.Fatalf("reservation %d not saved: %+v (%v)", reservation.ID, stayRooms, err)
+			}
+		}
+	})
+}
+
+func TestCreateReservationsPromoLimits(t *testing.T) {
+	forEachBackend(t, newTestFixture(), func(t *testing.T, repo HotelMgmtRepo) {
+		dates := []string{"2022-12-01"}
+		book := func(customers ...string) error {
+			reservations := make([]*models.Reservation, 0, len(customers))
+			roomIDs := make([][]int, 0, len(customers))
+			for i, customer := range customers {
+				reservations = append(reservations, newTestReservation(customer, "2022-12-01", "2022-12-02", 1))
+				roomIDs = append(roomIDs, []int{i + 1})
+			}
+			return repo.CreateReservations(reservations, models.OrderStatusPending, roomIDs, dates)
+		}
+
+		//the limits count the redemptions of earlier reservations of the same call
+		if err := book("alice", "Alice"); !errors.Is(err, ErrPromoCustomerLimit) {
+			t.Fatalf("expected customer limit error, got %v", err)
+		}
+		if err := book("alice", "bob", "carol"); !errors.Is(err, ErrPromoFullyRedeemed) {
+			t.Fatalf("expected fully redeemed error, got %v", err)
+		}
+		if promo, err := repo.FindPromoByID(1); err != nil || promo.RedemptionCount != 0 {
+			t.Fatalf("rejected batches left redemptions behind: %+v (%v)", promo, err)
+		}
+		if ids, _ := repo.FindBookedRoomIDs(1, "2022-12-01", "2022-12-02"); len(ids) != 0 {
+			t.Fatalf("rejected batches left stay rooms behind: %v", ids)
+		}
+	})
+}
+
+func TestBackfillCustomerKeys(t *testing.T) {
+	db := newSQLiteTestDB(t)
+	repo := newHotelMgmtRepo(db)
+	if err := seedFixture(db, newTestFixture()); err != nil {
+		t.Fatalf("seed sqlite: %v", err)
+	}
+	reservation := newTestReservation("John  Doe", "2022-12-01", "2022-12-02", 1)
+	if err := repo.CreateReservation(reservation, models.OrderStatusPending, []int{1}, []string{"2022-12-01"}); err != nil {
+		t.Fatalf("create reservation: %v", err)
+	}
+
+	//a redemption saved before customer keys
+	db.Model(&models.PromoRedemption{}).Where("reservation_id = ?", reservation.ID).UpdateColumn("customer_key", "")
+	backfillCustomerKeys(db)
+	if err := repo.CreateReservation(newTestReservation("john doe", "2022-12-01", "2022-12-02", 1), models.OrderStatusPending, []int{2}, []string{"2022-12-01"}); !errors.Is(err, ErrPromoCustomerLimit) {
+		t.Fatalf("expected backfilled redemption to count against the customer limit, got %v", err)
+	}
+}
+
+func TestPromosWithoutCode(t *testing.T) {
+	db := newSQLiteTestDB(t)
+	newHotelMgmtRepo(db)
+	for _, promo := range []*models.Promo{{Currency: 10000}, {Currency: 20000}, {Code: promoCode("WEEKEND10")}} {
+		if err := db.Create(promo).Error; err != nil {
+			t.Fatalf("create promo %+v: %v", promo, err)
+		}
+	}
+	if err := db.Create(&models.Promo{Code: promoCode("WEEKEND10")}).Error; err == nil {
+		t.Fatal("expected duplicate promo code to fail")
+	}
+}
@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+//fetching blocks of the rooms of a hotel overlapping from date until to date
+func (repo *hotelMgmtRepo) FindRoomBlocks(hotelID int, fromDate string, toDate string) (blocks []*models.RoomBlock, err error) {
+	if err = repo.connection.Debug().Joins("JOIN rooms ON rooms.id = room_blocks.room_id").
+		Where("rooms.hotel_id = ? AND room_blocks.from_date < ? AND room_blocks.until_date > ?", hotelID, toDate, fromDate).
+		Select("room_blocks.*").Order("room_blocks.from_date, room_blocks.id").Find(&blocks).Error; err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+//fetching room block by id
+func (repo *hotelMgmtRepo) FindRoomBlockByID(id int) (*models.RoomBlock, error) {
+	var block models.RoomBlock
+	if err := repo.connection.Debug().Where("id = ?", id).First(&block).Error; err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+//create room block
+func (repo *hotelMgmtRepo) CreateRoomBlock(block *models.RoomBlock) error {
+	return repo.connection.Debug().Set("gorm:save_associations", false).Create(block).Error
+}
+
+//update dates and reason of a room block
+func (repo *hotelMgmtRepo) UpdateRoomBlock(block *models.RoomBlock) error {
+	return repo.connection.Debug().Model(&models.RoomBlock{}).Where("id = ?", block.ID).Updates(map[string]interface{}{
+		"room_id":    block.RoomID,
+		"from_date":  block.FromDate,
+		"until_date": block.UntilDate,
+		"reason":     block.Reason,
+	}).Error
+}
+
+//delete room block
+func (repo *hotelMgmtRepo) DeleteRoomBlock(id int) error {
+	deleted := repo.connection.Debug().Where("id = ?", id).Delete(&models.RoomBlock{})
+	if deleted.Error != nil {
+		return deleted.Error
+	}
+	if deleted.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+//create tables having foreign keys before auto migrate, sqlite only accepts constraints in CREATE TABLE
+func createSQLiteTables(db *gorm.DB) {
+	constraints := make(map[string][]string)
+	var models []interface{}
+	for _, fk := range foreignKeys {
+		tableName := db.NewScope(fk.model).TableName()
+		if _, ok := constraints[tableName]; !ok {
+			models = append(models, fk.model)
+		}
+		constraints[tableName] = append(constraints[tableName], fmt.Sprintf(
+			"FOREIGN KEY (%s) REFERENCES %s ON DELETE RESTRICT ON UPDATE RESTRICT", db.Dialect().Quote(fk.field), fk.dest))
+	}
+
+	for _, model := range models {
+		scope := db.NewScope(model)
+		if scope.Dialect().HasTable(scope.TableName()) {
+			continue
+		}
+		var columns []string
+		for _, field := range scope.GetModelStruct().StructFields {
+			if field.IsNormal {
+				columns = append(columns, scope.Quote(field.DBName)+" "+scope.Dialect().DataTypeOf(field))
+			}
+		}
+		columns = append(columns, constraints[scope.TableName()]...)
+		db.Exec(fmt.Sprintf("CREATE TABLE %s (%s)", scope.QuotedTableName(), strings.Join(columns, ",")))
+	}
+}
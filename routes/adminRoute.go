@@ -0,0 +1,88 @@
+package routes
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nurcholisnanda/hotel-management-system/controllers"
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+func SetAdminRoutes(route *gin.Engine, controller controllers.HotelMgmtController, adminToken string) {
+	grp1 := route.Group(applicationBasePath+"admin", adminAuth(adminToken))
+	{
+		grp1.GET("hotels", func(ctx *gin.Context) {
+			controller.GetAllHotels(ctx)
+		})
+		grp1.POST("hotels", func(ctx *gin.Context) {
+			controller.CreateHotel(ctx)
+		})
+		grp1.PUT("hotels/:id", func(ctx *gin.Context) {
+			controller.UpdateHotel(ctx)
+		})
+		grp1.POST("hotels/:id/deactivate", func(ctx *gin.Context) {
+			controller.DeactivateHotel(ctx)
+		})
+		grp1.GET("hotels/:id/rooms", func(ctx *gin.Context) {
+			controller.GetAllRooms(ctx)
+		})
+		grp1.GET("room-types", func(ctx *gin.Context) {
+			controller.GetRoomTypes(ctx)
+		})
+		grp1.POST("room-types", func(ctx *gin.Context) {
+			controller.CreateRoomType(ctx)
+		})
+		grp1.PUT("room-types/:id", func(ctx *gin.Context) {
+			controller.UpdateRoomType(ctx)
+		})
+		grp1.POST("room-types/:id/deactivate", func(ctx *gin.Context) {
+			controller.DeactivateRoomType(ctx)
+		})
+		grp1.POST("rooms", func(ctx *gin.Context) {
+			controller.CreateRoom(ctx)
+		})
+		grp1.PUT("rooms/:id", func(ctx *gin.Context) {
+			controller.UpdateRoom(ctx)
+		})
+		grp1.POST("rooms/:id/deactivate", func(ctx *gin.Context) {
+			controller.DeactivateRoom(ctx)
+		})
+	}
+
+	//rate calendar is managed by revenue managers holding the admin token
+	grp2 := route.Group(applicationBasePath+"hotels/:id/room-types/:room_type_id/prices", adminAuth(adminToken))
+	{
+		grp2.GET("", func(ctx *gin.Context) {
+			controller.GetPriceCalendar(ctx)
+		})
+		grp2.PUT("", func(ctx *gin.Context) {
+			controller.SetPriceRange(ctx)
+		})
+		grp2.POST("import", func(ctx *gin.Context) {
+			controller.ImportPrices(ctx)
+		})
+		grp2.GET("export", func(ctx *gin.Context) {
+			controller.ExportPrices(ctx)
+		})
+	}
+}
+
+//allow only requests with the admin token as bearer token, every request is refused when no token is configured
+func adminAuth(adminToken string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token := strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+		if adminToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, models.ErrResponse{
+				Status:    http.StatusUnauthorized,
+				Message:   "admin token is missing or invalid",
+				Success:   false,
+				Retryable: false,
+				ErrorCode: models.ErrorCodeUnauthorized,
+			})
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -1,15 +1,81 @@
 package routes
 
-import "github.com/gin-gonic/gin"
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nurcholisnanda/hotel-management-system/controllers"
+)
 
-func SetHotelManagementRoutes(route *gin.Engine) {
+func SetHotelManagementRoutes(route *gin.Engine, controller controllers.HotelMgmtController) {
 	grp1 := route.Group(applicationBasePath)
 	{
+		grp1.GET("hotels", func(ctx *gin.Context) {
+			controller.GetHotels(ctx)
+		})
 		grp1.GET("available-rooms", func(ctx *gin.Context) {
 			controller.GetAvailableRooms(ctx)
 		})
+		grp1.GET("available-rooms/flex", func(ctx *gin.Context) {
+			controller.GetFlexibleStays(ctx)
+		})
+		grp1.POST("available-rooms/multi", func(ctx *gin.Context) {
+			controller.GetMultiRoomAvailability(ctx)
+		})
+		grp1.GET("best-promo-rooms", func(ctx *gin.Context) {
+			controller.GetBestPromoRooms(ctx)
+		})
 		grp1.POST("promo-rooms", func(ctx *gin.Context) {
 			controller.GetPromoPriceRooms(ctx)
 		})
+		grp1.POST("reservations", func(ctx *gin.Context) {
+			controller.CreateReservation(ctx)
+		})
+		grp1.POST("reservations/multi", func(ctx *gin.Context) {
+			controller.CreateMultiRoomReservation(ctx)
+		})
+		grp1.PATCH("reservations/:id", func(ctx *gin.Context) {
+			controller.UpdateReservation(ctx)
+		})
+		grp1.POST("reservations/:id/cancel", func(ctx *gin.Context) {
+			controller.CancelReservation(ctx)
+		})
+		grp1.POST("reservations/:id/check-in", func(ctx *gin.Context) {
+			controller.CheckInReservation(ctx)
+		})
+		grp1.POST("reservations/:id/check-out", func(ctx *gin.Context) {
+			controller.CheckOutReservation(ctx)
+		})
+		grp1.PUT("rooms/:id/status", func(ctx *gin.Context) {
+			controller.UpdateRoomStatus(ctx)
+		})
+		grp1.GET("hotels/:id/housekeeping", func(ctx *gin.Context) {
+			controller.GetHousekeepingBoard(ctx)
+		})
+		grp1.GET("hotels/:id/availability-calendar", func(ctx *gin.Context) {
+			controller.GetAvailabilityCalendar(ctx)
+		})
+		grp1.GET("hotels/:id/room-blocks", func(ctx *gin.Context) {
+			controller.GetRoomBlocks(ctx)
+		})
+		grp1.GET("hotels/:id/room-blocks/report", func(ctx *gin.Context) {
+			controller.GetRoomBlockReport(ctx)
+		})
+		grp1.GET("room-blocks/:id", func(ctx *gin.Context) {
+			controller.GetRoomBlock(ctx)
+		})
+		grp1.POST("room-blocks", func(ctx *gin.Context) {
+			controller.CreateRoomBlock(ctx)
+		})
+		grp1.PUT("room-blocks/:id", func(ctx *gin.Context) {
+			controller.UpdateRoomBlock(ctx)
+		})
+		grp1.DELETE("room-blocks/:id", func(ctx *gin.Context) {
+			controller.DeleteRoomBlock(ctx)
+		})
+		grp1.POST("orders/:id/status", func(ctx *gin.Context) {
+			controller.ChangeOrderStatus(ctx)
+		})
+		grp1.GET("orders/:id/history", func(ctx *gin.Context) {
+			controller.GetOrderHistory(ctx)
+		})
 	}
 }
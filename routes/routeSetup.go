@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/nurcholisnanda/hotel-management-system/configs"
 	"github.com/nurcholisnanda/hotel-management-system/controllers"
 	"github.com/nurcholisnanda/hotel-management-system/docs"
 	"github.com/nurcholisnanda/hotel-management-system/repositories"
@@ -10,16 +11,17 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger" // gin-swagger middleware
 )
 
-var (
-	repository repositories.HotelMgmtRepo      = repositories.NewHotelMgmtRepo()
-	service    services.HotelMgmtService       = services.NewHotelMgmtService(repository)
-	controller controllers.HotelMgmtController = controllers.NewHotelMgmtController(service)
-)
-
 const applicationBasePath = "/"
 
-//SetupRouter ... Configure routes
+//SetupRouter ... Configure routes with the repository chosen by DB_DRIVER
 func SetupRouter() *gin.Engine {
+	return SetupRouterWithRepo(repositories.NewHotelMgmtRepo())
+}
+
+//SetupRouterWithRepo ... Configure routes on top of the given repository
+func SetupRouterWithRepo(repository repositories.HotelMgmtRepo) *gin.Engine {
+	service := services.NewHotelMgmtService(repository)
+	controller := controllers.NewHotelMgmtController(service)
 
 	// Swagger 2.0 Meta Information
 	docs.SwaggerInfo.Title = "Hotel Management API"
@@ -31,7 +33,8 @@ func SetupRouter() *gin.Engine {
 
 	server := gin.Default()
 
-	SetHotelManagementRoutes(server)
+	SetHotelManagementRoutes(server, controller)
+	SetAdminRoutes(server, controller, configs.AdminToken())
 
 	server.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
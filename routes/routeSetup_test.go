@@ -0,0 +1,572 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nurcholisnanda/hotel-management-system/models"
+	"github.com/nurcholisnanda/hotel-management-system/repositories"
+	"github.com/nurcholisnanda/hotel-management-system/services"
+)
+
+func serve(t *testing.T, router *gin.Engine, method string, target string, body interface{}, out interface{}) int {
+	t.Helper()
+	return serveWithToken(t, router, "", method, target, body, out)
+}
+
+func serveWithToken(t *testing.T, router *gin.Engine, token string, method string, target string, body interface{}, out interface{}) int {
+	t.Helper()
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			t.Fatalf("encode body: %v", err)
+		}
+	}
+	request := httptest.NewRequest(method, target, &payload)
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	if out != nil {
+		if err := json.Unmarshal(recorder.Body.Bytes(), out); err != nil {
+			t.Fatalf("decode %s %s response %q: %v", method, target, recorder.Body.String(), err)
+		}
+	}
+	return recorder.Code
+}
+
+func TestReservationFlowWithMemoryRepo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fixture := repositories.DemoFixture(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	router := SetupRouterWithRepo(repositories.NewMemoryHotelMgmtRepo(fixture))
+
+	var hotels []*models.Hotel
+	if code := serve(t, router, http.MethodGet, "/hotels", nil, &hotels); code != http.StatusOK || len(hotels) != 1 {
+		t.Fatalf("list hotels: status %d, hotels %d", code, len(hotels))
+	}
+
+	var availableRooms models.HotelAvailableRoomsResponse
+	code := serve(t, router, http.MethodGet, "/available-rooms?hotel_id=1&checkin_date=2030-01-07&checkout_date=2030-01-09&room_qty=2&room_type_id=1", nil, &availableRooms)
+	if code != http.StatusOK || len(availableRooms.AvailableRooms) != 5 || availableRooms.TotalPrice != 2000000 {
+		t.Fatalf("search rooms: status %d, rooms %d, total price %d", code, len(availableRooms.AvailableRooms), availableRooms.TotalPrice)
+	}
+
+	request := models.ReservationRequest{
+		CustomerName:   "Budi",
+		HotelID:        1,
+		RoomQty:        2,
+		RoomTypeID:     1,
+		CheckinDate:    "2030-01-07",
+		CheckoutDate:   "2030-01-09",
+		AvailableRooms: availableRooms.AvailableRooms[3:],
+	}
+	var reservation models.ReservationResponse
+	if code := serve(t, router, http.MethodPost, "/reservations", request, &reservation); code != http.StatusCreated {
+		t.Fatalf("create reservation: status %d", code)
+	}
+	if reservation.FinalPrice != 2000000 || len(reservation.Rooms) != 2 || reservation.Rooms[0].ID != 4 || reservation.Rooms[1].ID != 5 {
+		t.Fatalf("unexpected reservation %+v", reservation)
+	}
+
+	request.RoomQty = 4
+	var errResponse models.ErrResponse
+	if code := serve(t, router, http.MethodPost, "/reservations", request, &errResponse); code != http.StatusConflict || errResponse.ErrorCode != models.ErrorCodeNoAvailability {
+		t.Fatalf("overbooking: expected status 409 and no availability, got %d %+v", code, errResponse)
+	}
+
+	var history models.OrderHistoryResponse
+	statusTarget := fmt.Sprintf("/orders/%d/status", reservation.OrderID)
+	if code := serve(t, router, http.MethodPost, statusTarget, models.OrderStatusRequest{Status: models.OrderStatusConfirmed}, &history); code != http.StatusOK {
+		t.Fatalf("confirm order: status %d", code)
+	}
+	if code := serve(t, router, http.MethodPost, statusTarget, models.OrderStatusRequest{Status: models.OrderStatusCheckedOut}, &errResponse); code != http.StatusConflict {
+		t.Fatalf("skip check-in: expected status 409, got %d", code)
+	}
+
+	var cancellation models.CancellationResponse
+	target := fmt.Sprintf("/reservations/%d/cancel", reservation.ReservationID)
+	if code := serve(t, router, http.MethodPost, target, nil, &cancellation); code != http.StatusOK {
+		t.Fatalf("cancel reservation: status %d", code)
+	}
+	if cancellation.OrderStatus != models.OrderStatusCancelled || cancellation.CancellationFee != 0 || cancellation.RefundAmount != 2000000 {
+		t.Fatalf("unexpected cancellation %+v", cancellation)
+	}
+	if code := serve(t, router, http.MethodPost, target, nil, &errResponse); code != http.StatusConflict {
+		t.Fatalf("cancel twice: expected status 409, got %d", code)
+	}
+	if code := serve(t, router, http.MethodGet, fmt.Sprintf("/orders/%d/history", reservation.OrderID), nil, &history); code != http.StatusOK ||
+		history.OrderStatus != models.OrderStatusCancelled || len(history.History) != 3 {
+		t.Fatalf("order history: status %d, history %+v", code, history)
+	}
+	if code := serve(t, router, http.MethodPost, "/reservations", request, &reservation); code != http.StatusCreated {
+		t.Fatalf("book released rooms: status %d", code)
+	}
+}
+
+func TestUpdateReservationWithMemoryRepo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fixture := repositories.DemoFixture(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	router := SetupRouterWithRepo(repositories.NewMemoryHotelMgmtRepo(fixture))
+
+	//three weekday nights qualify for the long stay promo
+	request := models.ReservationRequest{
+		CustomerName: "Budi",
+		HotelID:      1,
+		PromoCode:    "LONGSTAY",
+		RoomQty:      1,
+		RoomTypeID:   1,
+		CheckinDate:  "2030-01-07",
+		CheckoutDate: "2030-01-10",
+	}
+	var reservation models.ReservationResponse
+	if code := serve(t, router, http.MethodPost, "/reservations", request, &reservation); code != http.StatusCreated || reservation.FinalPrice != 1350000 {
+		t.Fatalf("create reservation: status %d, final price %d", code, reservation.FinalPrice)
+	}
+	target := fmt.Sprintf("/reservations/%d", reservation.ReservationID)
+
+	//shortening the stay loses the promo
+	var update models.ReservationUpdateResponse
+	if code := serve(t, router, http.MethodPatch, target, models.ReservationUpdateRequest{CheckoutDate: "2030-01-09"}, &update); code != http.StatusOK {
+		t.Fatalf("shorten stay: status %d", code)
+	}
+	if update.New.FinalPrice != 1000000 || update.PriceDifference != -350000 || len(update.ReleasedNights) != 1 ||
+		len(update.AddedNights) != 0 || len(update.RemovedPromoIDs) != 1 {
+		t.Fatalf("unexpected update %+v", update)
+	}
+
+	//adding a room only sells the nights of the new room
+	if code := serve(t, router, http.MethodPatch, target, models.ReservationUpdateRequest{RoomQty: 2}, &update); code != http.StatusOK {
+		t.Fatalf("add room: status %d", code)
+	}
+	if update.New.FinalPrice != 2000000 || len(update.AddedNights) != 2 || len(update.ReleasedNights) != 0 ||
+		len(update.Rooms) != 2 || update.Rooms[0].ID != reservation.Rooms[0].ID {
+		t.Fatalf("unexpected update %+v", update)
+	}
+
+	var availableRooms models.HotelAvailableRoomsResponse
+	code := serve(t, router, http.MethodGet, "/available-rooms?hotel_id=1&checkin_date=2030-01-07&checkout_date=2030-01-09&room_qty=1&room_type_id=1", nil, &availableRooms)
+	if code != http.StatusOK || len(availableRooms.AvailableRooms) != 3 {
+		t.Fatalf("search rooms: status %d, rooms %d", code, len(availableRooms.AvailableRooms))
+	}
+}
+
+func TestFrontDeskWithMemoryRepo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatalf("load timezone: %v", err)
+	}
+	today := time.Now().In(jakarta)
+	router := SetupRouterWithRepo(repositories.NewMemoryHotelMgmtRepo(repositories.DemoFixture(today.AddDate(0, 0, -1))))
+
+	request := models.ReservationRequest{
+		CustomerName: "Budi",
+		HotelID:      1,
+		RoomQty:      2,
+		RoomTypeID:   2,
+		CheckinDate:  today.Format("2006-01-02"),
+		CheckoutDate: today.AddDate(0, 0, 3).Format("2006-01-02"),
+	}
+	var reservation models.ReservationResponse
+	if code := serve(t, router, http.MethodPost, "/reservations", request, &reservation); code != http.StatusCreated {
+		t.Fatalf("create reservation: status %d", code)
+	}
+	checkIn := models.CheckInRequest{Guests: []*models.CheckInGuest{
+		{GuestName: "Budi"},
+		{GuestName: "Sari", RoomNumber: reservation.Rooms[0].RoomNumber},
+	}}
+	var errResponse models.ErrResponse
+	target := fmt.Sprintf("/reservations/%d", reservation.ReservationID)
+	if code := serve(t, router, http.MethodPost, target+"/check-in", checkIn, &errResponse); code != http.StatusConflict {
+		t.Fatalf("check in pending order: expected status 409, got %d", code)
+	}
+	var history models.OrderHistoryResponse
+	if code := serve(t, router, http.MethodPost, fmt.Sprintf("/orders/%d/status", reservation.OrderID), models.OrderStatusRequest{Status: models.OrderStatusConfirmed}, &history); code != http.StatusOK {
+		t.Fatalf("confirm order: status %d", code)
+	}
+
+	var frontDesk models.FrontDeskResponse
+	if code := serve(t, router, http.MethodPost, target+"/check-in", checkIn, &frontDesk); code != http.StatusOK {
+		t.Fatalf("check in: status %d", code)
+	}
+	if frontDesk.OrderStatus != models.OrderStatusCheckedIn || frontDesk.Stays[0].GuestName != "Sari" ||
+		frontDesk.Stays[1].GuestName != "Budi" || frontDesk.Stays[0].CheckedInAt == nil {
+		t.Fatalf("unexpected check in %+v", frontDesk)
+	}
+
+	//leaving on the arrival day keeps the first night and releases the other two of each room without refund
+	if code := serve(t, router, http.MethodPost, target+"/check-out", nil, &frontDesk); code != http.StatusOK {
+		t.Fatalf("check out: status %d", code)
+	}
+	if frontDesk.OrderStatus != models.OrderStatusCheckedOut || len(frontDesk.ReleasedNights) != 4 || frontDesk.Stays[1].CheckedOutAt == nil {
+		t.Fatalf("unexpected check out %+v", frontDesk)
+	}
+	if frontDesk.FinalPrice != reservation.FinalPrice {
+		t.Fatalf("expected final price %d without refund, got %d", reservation.FinalPrice, frontDesk.FinalPrice)
+	}
+	if code := serve(t, router, http.MethodPost, target+"/check-out", nil, &errResponse); code != http.StatusConflict {
+		t.Fatalf("check out twice: expected status 409, got %d", code)
+	}
+}
+
+func TestHousekeepingWithMemoryRepo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fixture := repositories.DemoFixture(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	router := SetupRouterWithRepo(repositories.NewMemoryHotelMgmtRepo(fixture))
+
+	request := models.ReservationRequest{
+		CustomerName: "Budi",
+		HotelID:      1,
+		RoomQty:      1,
+		RoomTypeID:   1,
+		CheckinDate:  "2030-01-07",
+		CheckoutDate: "2030-01-09",
+	}
+	var reservation models.ReservationResponse
+	if code := serve(t, router, http.MethodPost, "/reservations", request, &reservation); code != http.StatusCreated {
+		t.Fatalf("create reservation: status %d", code)
+	}
+
+	//a sold room can't be taken out of order, a free one leaves the availability search
+	var errResponse models.ErrResponse
+	outOfOrder := models.RoomStatusRequest{Status: models.RoomStatusOutOfOrder, FromDate: "2030-01-08", UntilDate: "2030-01-10"}
+	if code := serve(t, router, http.MethodPut, fmt.Sprintf("/rooms/%d/status", reservation.Rooms[0].ID), outOfOrder, &errResponse); code != http.StatusConflict {
+		t.Fatalf("out of order sold room: expected status 409, got %d", code)
+	}
+	var room models.Room
+	if code := serve(t, router, http.MethodPut, "/rooms/2/status", outOfOrder, &room); code != http.StatusOK || room.RoomStatus != models.RoomStatusOutOfOrder {
+		t.Fatalf("out of order room: status %d, room %+v", code, room)
+	}
+	var availableRooms models.HotelAvailableRoomsResponse
+	if code := serve(t, router, http.MethodGet, "/available-rooms?hotel_id=1&checkin_date=2030-01-07&checkout_date=2030-01-09&room_qty=1&room_type_id=1", nil, &availableRooms); code != http.StatusOK || len(availableRooms.AvailableRooms) != 3 {
+		t.Fatalf("search rooms: status %d, rooms %d", code, len(availableRooms.AvailableRooms))
+	}
+	if code := serve(t, router, http.MethodPut, "/rooms/3/status", models.RoomStatusRequest{Status: "sparkling"}, &errResponse); code != http.StatusConflict {
+		t.Fatalf("unknown status: expected status 409, got %d", code)
+	}
+
+	var board models.HousekeepingBoardResponse
+	if code := serve(t, router, http.MethodGet, "/hotels/1/housekeeping?date=2030-01-09", nil, &board); code != http.StatusOK || len(board.Tasks) != 10 {
+		t.Fatalf("task board: status %d, tasks %d", code, len(board.Tasks))
+	}
+	for _, task := range board.Tasks {
+		want := models.TaskNone
+		switch task.RoomID {
+		case reservation.Rooms[0].ID:
+			want = models.TaskClean
+		case 2:
+			want = models.TaskMaintenance
+		}
+		if task.Task != want {
+			t.Fatalf("room %d: expected task %s, got %s (%s)", task.RoomNumber, want, task.Task, task.Occupancy)
+		}
+	}
+}
+
+func TestRoomBlocksWithMemoryRepo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fixture := repositories.DemoFixture(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	router := SetupRouterWithRepo(repositories.NewMemoryHotelMgmtRepo(fixture))
+
+	request := models.ReservationRequest{
+		CustomerName: "Budi",
+		HotelID:      1,
+		RoomQty:      1,
+		RoomTypeID:   1,
+		CheckinDate:  "2030-01-07",
+		CheckoutDate: "2030-01-09",
+	}
+	var reservation models.ReservationResponse
+	if code := serve(t, router, http.MethodPost, "/reservations", request, &reservation); code != http.StatusCreated {
+		t.Fatalf("create reservation: status %d", code)
+	}
+
+	//a sold room can't be blocked, the conflicting nights are listed
+	var conflict models.RoomBlockConflictResponse
+	soldBlock := models.RoomBlockRequest{RoomID: reservation.Rooms[0].ID, FromDate: "2030-01-06", UntilDate: "2030-01-10", Reason: "leak"}
+	if code := serve(t, router, http.MethodPost, "/room-blocks", soldBlock, &conflict); code != http.StatusConflict || len(conflict.Conflicts) != 2 {
+		t.Fatalf("block sold room: status %d, conflicts %+v", code, conflict.Conflicts)
+	}
+
+	var block models.RoomBlock
+	freeBlock := models.RoomBlockRequest{RoomID: 2, FromDate: "2030-01-08", UntilDate: "2030-01-10", Reason: "leak"}
+	if code := serve(t, router, http.MethodPost, "/room-blocks", freeBlock, &block); code != http.StatusCreated || block.ID == 0 {
+		t.Fatalf("block room: status %d, block %+v", code, block)
+	}
+	var errResponse models.ErrResponse
+	if code := serve(t, router, http.MethodPost, "/room-blocks", freeBlock, &errResponse); code != http.StatusConflict {
+		t.Fatalf("block room twice: expected status 409, got %d", code)
+	}
+
+	var availableRooms models.HotelAvailableRoomsResponse
+	if code := serve(t, router, http.MethodGet, "/available-rooms?hotel_id=1&checkin_date=2030-01-07&checkout_date=2030-01-09&room_qty=1&room_type_id=1", nil, &availableRooms); code != http.StatusOK || len(availableRooms.AvailableRooms) != 3 {
+		t.Fatalf("search rooms: status %d, rooms %d", code, len(availableRooms.AvailableRooms))
+	}
+
+	var report models.RoomBlockReportResponse
+	if code := serve(t, router, http.MethodGet, "/hotels/1/room-blocks/report?from_date=2030-01-09&to_date=2030-01-31", nil, &report); code != http.StatusOK || report.BlockedNights != 1 {
+		t.Fatalf("block report: status %d, nights %d", code, report.BlockedNights)
+	}
+
+	target := fmt.Sprintf("/room-blocks/%d", block.ID)
+	if code := serve(t, router, http.MethodDelete, target, nil, nil); code != http.StatusNoContent {
+		t.Fatalf("delete room block: status %d", code)
+	}
+	if code := serve(t, router, http.MethodGet, target, nil, &errResponse); code != http.StatusNotFound {
+		t.Fatalf("get deleted room block: expected status 404, got %d", code)
+	}
+}
+
+func TestAdminWithMemoryRepo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	os.Setenv("ADMIN_TOKEN", "secret")
+	defer os.Unsetenv("ADMIN_TOKEN")
+	fixture := repositories.DemoFixture(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	router := SetupRouterWithRepo(repositories.NewMemoryHotelMgmtRepo(fixture))
+
+	var errResponse models.ErrResponse
+	if code := serveWithToken(t, router, "wrong", http.MethodGet, "/admin/hotels", nil, &errResponse); code != http.StatusUnauthorized {
+		t.Fatalf("wrong token: expected status 401, got %d", code)
+	}
+	if errResponse.ErrorCode != models.ErrorCodeUnauthorized || errResponse.Retryable {
+		t.Fatalf("wrong token: unexpected error %+v", errResponse)
+	}
+
+	var hotel models.Hotel
+	if code := serveWithToken(t, router, "secret", http.MethodPost, "/admin/hotels", models.HotelRequest{HotelName: "Seaside", Timezone: "Mars/Olympus"}, &errResponse); code != http.StatusBadRequest {
+		t.Fatalf("invalid timezone: expected status 400, got %d", code)
+	}
+	if code := serveWithToken(t, router, "secret", http.MethodPost, "/admin/hotels", models.HotelRequest{HotelName: "Seaside", Timezone: "Asia/Makassar"}, &hotel); code != http.StatusCreated || hotel.ID == 0 {
+		t.Fatalf("create hotel: status %d, hotel %+v", code, hotel)
+	}
+	var roomType models.RoomType
+	if code := serveWithToken(t, router, "secret", http.MethodPost, "/admin/room-types", models.RoomTypeRequest{Name: "Villa"}, &roomType); code != http.StatusCreated || roomType.ID == 0 {
+		t.Fatalf("create room type: status %d, room type %+v", code, roomType)
+	}
+
+	//room numbers are unique in a hotel and the room type must exist
+	var villa, room models.RoomResponse
+	if code := serveWithToken(t, router, "secret", http.MethodPost, "/admin/rooms", models.RoomRequest{HotelID: hotel.ID, RoomTypeID: 99, RoomNumber: 1}, &errResponse); code != http.StatusBadRequest {
+		t.Fatalf("missing room type: expected status 400, got %d", code)
+	}
+	if code := serveWithToken(t, router, "secret", http.MethodPost, "/admin/rooms", models.RoomRequest{HotelID: hotel.ID, RoomTypeID: roomType.ID, RoomNumber: 1}, &villa); code != http.StatusCreated || villa.RoomID == 0 {
+		t.Fatalf("create room: status %d, room %+v", code, villa)
+	}
+	if code := serveWithToken(t, router, "secret", http.MethodPost, "/admin/rooms", models.RoomRequest{HotelID: hotel.ID, RoomTypeID: roomType.ID, RoomNumber: 1}, &errResponse); code != http.StatusConflict {
+		t.Fatalf("duplicate room number: expected status 409, got %d", code)
+	}
+
+	//sold rooms can't be deactivated, a hotel needs its rooms deactivated first
+	request := models.ReservationRequest{
+		CustomerName: "Budi",
+		HotelID:      1,
+		RoomQty:      1,
+		RoomTypeID:   1,
+		CheckinDate:  "2030-01-07",
+		CheckoutDate: "2030-01-09",
+	}
+	var reservation models.ReservationResponse
+	if code := serve(t, router, http.MethodPost, "/reservations", request, &reservation); code != http.StatusCreated {
+		t.Fatalf("create reservation: status %d", code)
+	}
+	if code := serveWithToken(t, router, "secret", http.MethodPost, fmt.Sprintf("/admin/rooms/%d/deactivate", reservation.Rooms[0].ID), nil, &errResponse); code != http.StatusConflict {
+		t.Fatalf("deactivate sold room: expected status 409, got %d", code)
+	}
+	if code := serveWithToken(t, router, "secret", http.MethodPost, "/admin/rooms/2/deactivate", nil, &room); code != http.StatusOK || room.DeactivatedAt == nil {
+		t.Fatalf("deactivate room: status %d, room %+v", code, room)
+	}
+	var availableRooms models.HotelAvailableRoomsResponse
+	if code := serve(t, router, http.MethodGet, "/available-rooms?hotel_id=1&checkin_date=2030-01-07&checkout_date=2030-01-09&room_qty=1&room_type_id=1", nil, &availableRooms); code != http.StatusOK || len(availableRooms.AvailableRooms) != 3 {
+		t.Fatalf("search rooms: status %d, rooms %d", code, len(availableRooms.AvailableRooms))
+	}
+	if code := serveWithToken(t, router, "secret", http.MethodPost, fmt.Sprintf("/admin/hotels/%d/deactivate", hotel.ID), nil, &errResponse); code != http.StatusConflict {
+		t.Fatalf("deactivate hotel with rooms: expected status 409, got %d", code)
+	}
+
+	if code := serveWithToken(t, router, "secret", http.MethodPost, fmt.Sprintf("/admin/rooms/%d/deactivate", villa.RoomID), nil, &villa); code != http.StatusOK {
+		t.Fatalf("deactivate villa: status %d", code)
+	}
+	if code := serveWithToken(t, router, "secret", http.MethodPost, fmt.Sprintf("/admin/hotels/%d/deactivate", hotel.ID), nil, &hotel); code != http.StatusOK || hotel.DeactivatedAt == nil {
+		t.Fatalf("deactivate hotel: status %d, hotel %+v", code, hotel)
+	}
+	var hotels []*models.Hotel
+	if code := serve(t, router, http.MethodGet, "/hotels", nil, &hotels); code != http.StatusOK || len(hotels) != 1 {
+		t.Fatalf("list hotels: status %d, hotels %d", code, len(hotels))
+	}
+	if code := serveWithToken(t, router, "secret", http.MethodGet, "/admin/hotels", nil, &hotels); code != http.StatusOK || len(hotels) != 2 {
+		t.Fatalf("list all hotels: status %d, hotels %d", code, len(hotels))
+	}
+}
+
+func TestStayValidationWithMemoryRepo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fixture := repositories.DemoFixture(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	router := SetupRouterWithRepo(repositories.NewMemoryHotelMgmtRepo(fixture))
+
+	//every invalid field is reported at once
+	var errResponse models.ErrResponse
+	code := serve(t, router, http.MethodGet, "/available-rooms?hotel_id=1&checkin_date=2030-01-09&checkout_date=2030-01-07&room_qty=0&room_type_id=1", nil, &errResponse)
+	if code != http.StatusBadRequest || errResponse.ErrorCode != models.ErrorCodeValidation || len(errResponse.Errors) != 1 || errResponse.Errors[0].Field != "room_qty" {
+		t.Fatalf("invalid query: status %d, response %+v", code, errResponse)
+	}
+
+	//checkout before checkin is caught by the service
+	errResponse = models.ErrResponse{}
+	code = serve(t, router, http.MethodGet, "/available-rooms?hotel_id=1&checkin_date=2030-01-09&checkout_date=2030-01-07&room_qty=1&room_type_id=1", nil, &errResponse)
+	if code != http.StatusBadRequest || len(errResponse.Errors) != 1 || errResponse.Errors[0].Rule != services.RuleAfter {
+		t.Fatalf("reversed dates: status %d, response %+v", code, errResponse)
+	}
+
+	errResponse = models.ErrResponse{}
+	request := models.ReservationRequest{
+		CustomerName: "Budi",
+		HotelID:      1,
+		RoomQty:      1,
+		RoomTypeID:   1,
+		CheckinDate:  "2020-01-07",
+		CheckoutDate: "2020-01-09",
+	}
+	if code := serve(t, router, http.MethodPost, "/reservations", request, &errResponse); code != http.StatusBadRequest || errResponse.Errors[0].Rule != services.RuleNotPast {
+		t.Fatalf("past reservation: status %d, response %+v", code, errResponse)
+	}
+}
+
+func TestErrorCodesWithMemoryRepo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fixture := repositories.DemoFixture(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	router := SetupRouterWithRepo(repositories.NewMemoryHotelMgmtRepo(fixture))
+
+	request := models.PromoRoomsRequest{
+		PromoCode:    "NOPE",
+		HotelID:      1,
+		RoomQty:      1,
+		RoomTypeID:   1,
+		CheckinDate:  "2030-01-07",
+		CheckoutDate: "2030-01-09",
+	}
+	var errResponse models.ErrResponse
+	if code := serve(t, router, http.MethodPost, "/promo-rooms", request, &errResponse); code != http.StatusNotFound || errResponse.ErrorCode != models.ErrorCodePromoNotFound {
+		t.Fatalf("unknown promo: status %d, response %+v", code, errResponse)
+	}
+
+	//LONGSTAY needs 3 nights, the promo and the failed rule are in the details
+	request.PromoCode = "LONGSTAY"
+	var ineligible struct {
+		ErrorCode string `json:"error_code"`
+		Details   struct {
+			PromoID     int                        `json:"promo_id"`
+			FailedRules []*models.PromoRuleFailure `json:"failed_rules"`
+		} `json:"details"`
+	}
+	if code := serve(t, router, http.MethodPost, "/promo-rooms", request, &ineligible); code != http.StatusUnprocessableEntity ||
+		ineligible.ErrorCode != models.ErrorCodePromoIneligible || ineligible.Details.PromoID != 2 || len(ineligible.Details.FailedRules) != 1 {
+		t.Fatalf("ineligible promo: status %d, response %+v", code, ineligible)
+	}
+	if failure := ineligible.Details.FailedRules[0]; failure.Rule != services.PromoRuleMinimumNights || failure.Have != 2 || failure.Need != 3 || failure.Hint != "book 1 more night to qualify" {
+		t.Fatalf("unexpected failed rule %+v", failure)
+	}
+
+	errResponse = models.ErrResponse{}
+	if code := serve(t, router, http.MethodGet, "/room-blocks/99", nil, &errResponse); code != http.StatusNotFound || errResponse.ErrorCode != models.ErrorCodeNotFound {
+		t.Fatalf("unknown room block: status %d, response %+v", code, errResponse)
+	}
+}
+
+func TestFlexibleStaysWithMemoryRepo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fixture := repositories.DemoFixture(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	router := SetupRouterWithRepo(repositories.NewMemoryHotelMgmtRepo(fixture))
+
+	var res models.FlexStaysResponse
+	if code := serve(t, router, http.MethodGet, "/available-rooms/flex?hotel_id=1&room_type_id=1&from_date=2030-01-05&to_date=2030-01-12&nights=3", nil, &res); code != http.StatusOK {
+		t.Fatalf("flexible stays: status %d", code)
+	}
+	if len(res.Calendar) != 5 || len(res.Stays) == 0 || res.RoomQty != 1 {
+		t.Fatalf("unexpected flexible stays %+v", res)
+	}
+	for i := 1; i < len(res.Stays); i++ {
+		if res.Stays[i].TotalPrice < res.Stays[i-1].TotalPrice {
+			t.Fatalf("stays are not sorted by price: %+v", res.Stays)
+		}
+	}
+
+	var errResponse models.ErrResponse
+	if code := serve(t, router, http.MethodGet, "/available-rooms/flex?hotel_id=1&room_type_id=1&from_date=2030-01-05&to_date=2030-01-07&nights=3", nil, &errResponse); code != http.StatusBadRequest || errResponse.ErrorCode != models.ErrorCodeValidation {
+		t.Fatalf("window shorter than stay: status %d, response %+v", code, errResponse)
+	}
+}
+
+func TestAvailabilityCalendarWithMemoryRepo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fixture := repositories.DemoFixture(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	router := SetupRouterWithRepo(repositories.NewMemoryHotelMgmtRepo(fixture))
+
+	var res models.AvailabilityCalendarResponse
+	if code := serve(t, router, http.MethodGet, "/hotels/1/availability-calendar?room_type_id=1&from=2030-01-05&to=2030-02-05", nil, &res); code != http.StatusOK {
+		t.Fatalf("availability calendar: status %d", code)
+	}
+	if len(res.Days) != 31 || res.Days[0].Date != "2030-01-05" {
+		t.Fatalf("unexpected availability calendar %+v", res)
+	}
+	for _, day := range res.Days {
+		if day.TotalRooms == 0 || day.AvailableRooms != day.TotalRooms-day.SoldRooms-day.BlockedRooms {
+			t.Fatalf("unexpected room counts on %+v", day)
+		}
+	}
+
+	var errResponse models.ErrResponse
+	if code := serve(t, router, http.MethodGet, "/hotels/1/availability-calendar?from=2030-01-05&to=2030-02-05", nil, &errResponse); code != http.StatusBadRequest || errResponse.ErrorCode != models.ErrorCodeValidation {
+		t.Fatalf("missing room type: status %d, response %+v", code, errResponse)
+	}
+	if code := serve(t, router, http.MethodGet, "/hotels/99/availability-calendar?room_type_id=1&from=2030-01-05&to=2030-02-05", nil, &errResponse); code != http.StatusNotFound {
+		t.Fatalf("unknown hotel: status %d", code)
+	}
+}
+
+func TestMultiRoomReservationWithMemoryRepo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fixture := repositories.DemoFixture(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	router := SetupRouterWithRepo(repositories.NewMemoryHotelMgmtRepo(fixture))
+
+	//one double and two singles
+	search := models.MultiRoomSearchRequest{HotelID: 1, CheckinDate: "2030-01-07", CheckoutDate: "2030-01-09", Lines: []*models.RoomLine{{RoomTypeID: 2, RoomQty: 1}, {RoomTypeID: 1, RoomQty: 2}}}
+	var found models.MultiRoomSearchResponse
+	if code := serve(t, router, http.MethodPost, "/available-rooms/multi", search, &found); code != http.StatusOK {
+		t.Fatalf("multi room search: status %d", code)
+	}
+	if !found.Available || found.RoomQty != 3 || len(found.Lines) != 2 || found.TotalPrice != found.Lines[0].TotalPrice+found.Lines[1].TotalPrice {
+		t.Fatalf("unexpected multi room search %+v", found)
+	}
+
+	booking := models.MultiRoomReservationRequest{CustomerName: "family", HotelID: 1, CheckinDate: search.CheckinDate, CheckoutDate: search.CheckoutDate, Lines: search.Lines}
+	var booked models.MultiRoomReservationResponse
+	if code := serve(t, router, http.MethodPost, "/reservations/multi", booking, &booked); code != http.StatusCreated {
+		t.Fatalf("multi room reservation: status %d", code)
+	}
+	if len(booked.Reservations) != 2 || booked.FinalPrice != found.TotalPrice || len(booked.Reservations[1].Rooms) != 2 {
+		t.Fatalf("unexpected multi room reservation %+v", booked)
+	}
+
+	//not enough singles left, the double must not be booked either
+	booking.Lines = []*models.RoomLine{{RoomTypeID: 2, RoomQty: 1}, {RoomTypeID: 1, RoomQty: 4}}
+	var errResponse models.ErrResponse
+	if code := serve(t, router, http.MethodPost, "/reservations/multi", booking, &errResponse); code != http.StatusConflict || errResponse.ErrorCode != models.ErrorCodeNoAvailability {
+		t.Fatalf("overbooked line: status %d, response %+v", code, errResponse)
+	}
+	if code := serve(t, router, http.MethodPost, "/available-rooms/multi", search, &found); code != http.StatusOK || found.Lines[0].AvailableRoomCount != 4 || found.Lines[1].AvailableRoomCount != 3 {
+		t.Fatalf("expected 4 doubles and 3 singles left, got %+v", found.Lines)
+	}
+
+	search.Lines = []*models.RoomLine{{RoomTypeID: 1, RoomQty: 1}, {RoomTypeID: 1, RoomQty: 1}}
+	if code := serve(t, router, http.MethodPost, "/available-rooms/multi", search, &errResponse); code != http.StatusBadRequest || errResponse.ErrorCode != models.ErrorCodeValidation {
+		t.Fatalf("room type listed twice: status %d, response %+v", code, errResponse)
+	}
+}
@@ -0,0 +1,301 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+	"github.com/nurcholisnanda/hotel-management-system/repositories"
+)
+
+var (
+	//ErrRoomNumberTaken is returned when another room of the hotel already has the room number
+	ErrRoomNumberTaken = repositories.ErrRoomNumberTaken
+	//ErrHasActiveRooms is returned when a hotel or room type with active rooms is deactivated
+	ErrHasActiveRooms = errors.New("deactivate the active rooms first")
+	//ErrInvalidRoom is returned when a room refers to a hotel or room type which is missing or deactivated
+	ErrInvalidRoom = errors.New("invalid room")
+	//ErrInvalidTimezone is returned when the hotel timezone isn't an IANA time zone name
+	ErrInvalidTimezone = errors.New("invalid timezone")
+)
+
+//function to list every hotel including deactivated ones
+func (service *hotelMgmtService) FindAllHotels() (hotels []*models.Hotel, err error) {
+	return service.repository.FindHotels()
+}
+
+//function to create hotel, hotels without timezone run on UTC
+func (service *hotelMgmtService) CreateHotel(req *models.HotelRequest) (hotel *models.Hotel, err error) {
+	hotel = &models.Hotel{}
+	if err = setHotel(hotel, req); err != nil {
+		return nil, err
+	}
+	if err = service.repository.CreateHotel(hotel); err != nil {
+		return nil, err
+	}
+	return hotel, nil
+}
+
+//function to change name, address and timezone of a hotel
+func (service *hotelMgmtService) UpdateHotel(id int, req *models.HotelRequest) (hotel *models.Hotel, err error) {
+	hotel, err = service.repository.FindHotelByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err = setHotel(hotel, req); err != nil {
+		return nil, err
+	}
+	if err = service.repository.UpdateHotel(hotel); err != nil {
+		return nil, err
+	}
+	return hotel, nil
+}
+
+//function to take a hotel off the hotel list, its rooms must be deactivated first
+func (service *hotelMgmtService) DeactivateHotel(id int) (hotel *models.Hotel, err error) {
+	hotel, err = service.repository.FindHotelByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if hotel.DeactivatedAt != nil {
+		return hotel, nil
+	}
+	rooms, err := service.repository.FindHotelRooms(id)
+	if err != nil {
+		return nil, err
+	}
+	if count := activeRoomCount(rooms); count > 0 {
+		return nil, fmt.Errorf("%w: hotel %d has %d active rooms", ErrHasActiveRooms, id, count)
+	}
+	now := service.clock.Now()
+	hotel.DeactivatedAt = &now
+	if err = service.repository.UpdateHotel(hotel); err != nil {
+		return nil, err
+	}
+	return hotel, nil
+}
+
+//function to list every room type including deactivated ones
+func (service *hotelMgmtService) FindRoomTypes() (roomTypes []*models.RoomType, err error) {
+	return service.repository.FindRoomTypes()
+}
+
+//function to create room type
+func (service *hotelMgmtService) CreateRoomType(req *models.RoomTypeRequest) (roomType *models.RoomType, err error) {
+	if req.BaseRate < 0 {
+		return nil, fmt.Errorf("%w: base rate can't be negative", ErrInvalidPrice)
+	}
+	roomType = &models.RoomType{Name: req.Name, BaseRate: req.BaseRate}
+	if err = service.repository.CreateRoomType(roomType); err != nil {
+		return nil, err
+	}
+	return roomType, nil
+}
+
+//function to change name and base rate of a room type
+func (service *hotelMgmtService) UpdateRoomType(id int, req *models.RoomTypeRequest) (roomType *models.RoomType, err error) {
+	if req.BaseRate < 0 {
+		return nil, fmt.Errorf("%w: base rate can't be negative", ErrInvalidPrice)
+	}
+	roomType, err = service.repository.FindRoomTypeByID(id)
+	if err != nil {
+		return nil, err
+	}
+	roomType.Name = req.Name
+	roomType.BaseRate = req.BaseRate
+	if err = service.repository.UpdateRoomType(roomType); err != nil {
+		return nil, err
+	}
+	return roomType, nil
+}
+
+//function to stop using a room type for new rooms, its rooms must be deactivated first
+func (service *hotelMgmtService) DeactivateRoomType(id int) (roomType *models.RoomType, err error) {
+	roomType, err = service.repository.FindRoomTypeByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if roomType.DeactivatedAt != nil {
+		return roomType, nil
+	}
+	rooms, err := service.repository.FindRoomTypeRooms(id)
+	if err != nil {
+		return nil, err
+	}
+	if count := activeRoomCount(rooms); count > 0 {
+		return nil, fmt.Errorf("%w: room type %d has %d active rooms", ErrHasActiveRooms, id, count)
+	}
+	now := service.clock.Now()
+	roomType.DeactivatedAt = &now
+	if err = service.repository.UpdateRoomType(roomType); err != nil {
+		return nil, err
+	}
+	return roomType, nil
+}
+
+//function to list every room of a hotel including deactivated ones
+func (service *hotelMgmtService) FindAllRooms(hotelID int) (res []*models.RoomResponse, err error) {
+	if _, err = service.repository.FindHotelByID(hotelID); err != nil {
+		return nil, err
+	}
+	rooms, err := service.repository.FindHotelRooms(hotelID)
+	if err != nil {
+		return nil, err
+	}
+	res = make([]*models.RoomResponse, 0, len(rooms))
+	for _, room := range rooms {
+		res = append(res, roomResponse(room))
+	}
+	return res, nil
+}
+
+//function to create room in an active hotel with an active room type
+func (service *hotelMgmtService) CreateRoom(req *models.RoomRequest) (res *models.RoomResponse, err error) {
+	room := &models.Room{
+		HotelID:    req.HotelID,
+		RoomTypeID: req.RoomTypeID,
+		RoomNumber: req.RoomNumber,
+	}
+	if err = service.validateRoom(room); err != nil {
+		return nil, err
+	}
+	if err = service.repository.CreateRoom(room); err != nil {
+		return nil, err
+	}
+	return roomResponse(room), nil
+}
+
+//function to change hotel, room type or room number of a room, rooms with future stays keep their hotel and room type
+func (service *hotelMgmtService) UpdateRoom(id int, req *models.RoomRequest) (res *models.RoomResponse, err error) {
+	room, err := service.repository.FindRoomByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if room.HotelID != req.HotelID || room.RoomTypeID != req.RoomTypeID {
+		if err = service.checkNoFutureStays(room); err != nil {
+			return nil, err
+		}
+	}
+	room.HotelID = req.HotelID
+	room.RoomTypeID = req.RoomTypeID
+	room.RoomNumber = req.RoomNumber
+	if err = service.validateRoom(room); err != nil {
+		return nil, err
+	}
+	if err = service.repository.UpdateRoom(room); err != nil {
+		return nil, err
+	}
+	return roomResponse(room), nil
+}
+
+//function to take a room out of inventory for good, rooms with future stays can't be deactivated
+func (service *hotelMgmtService) DeactivateRoom(id int) (res *models.RoomResponse, err error) {
+	room, err := service.repository.FindRoomByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if room.DeactivatedAt != nil {
+		return roomResponse(room), nil
+	}
+	if err = service.checkNoFutureStays(room); err != nil {
+		return nil, err
+	}
+	now := service.clock.Now()
+	room.DeactivatedAt = &now
+	if err = service.repository.UpdateRoom(room); err != nil {
+		return nil, err
+	}
+	return roomResponse(room), nil
+}
+
+//check timezone and stay rules of a hotel request and copy them to the hotel
+func setHotel(hotel *models.Hotel, req *models.HotelRequest) error {
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("%w %q", ErrInvalidTimezone, timezone)
+	}
+	invalid := &ValidationError{}
+	if req.MinStayNights < 0 {
+		invalid.Add("min_stay_nights", RuleMin, "min_stay_nights can't be negative")
+	}
+	if req.MaxStayNights < 0 {
+		invalid.Add("max_stay_nights", RuleMin, "max_stay_nights can't be negative")
+	}
+	if req.MaxStayNights > 0 && req.MaxStayNights < req.MinStayNights {
+		invalid.Add("max_stay_nights", RuleMin, "max_stay_nights can't be less than min_stay_nights")
+	}
+	if req.BookingHorizon < 0 {
+		invalid.Add("booking_horizon_days", RuleMin, "booking_horizon_days can't be negative")
+	}
+	if err := invalid.Err(); err != nil {
+		return err
+	}
+	hotel.HotelName = req.HotelName
+	hotel.Address = req.Address
+	hotel.Timezone = timezone
+	hotel.MinStayNights = req.MinStayNights
+	hotel.MaxStayNights = req.MaxStayNights
+	hotel.BookingHorizon = req.BookingHorizon
+	return nil
+}
+
+//check room number, hotel and room type of a room, the hotel and room type must be active
+func (service *hotelMgmtService) validateRoom(room *models.Room) error {
+	if room.RoomNumber < 1 {
+		return fmt.Errorf("%w: room number must be greater than 0", ErrInvalidRoom)
+	}
+	hotel, err := service.repository.FindHotelByID(room.HotelID)
+	if err != nil || hotel.DeactivatedAt != nil {
+		return fmt.Errorf("%w: hotel %d doesn't exist or is deactivated", ErrInvalidRoom, room.HotelID)
+	}
+	roomType, err := service.repository.FindRoomTypeByID(room.RoomTypeID)
+	if err != nil || roomType.DeactivatedAt != nil {
+		return fmt.Errorf("%w: room type %d doesn't exist or is deactivated", ErrInvalidRoom, room.RoomTypeID)
+	}
+	return nil
+}
+
+//check the room isn't sold for tonight or any later night in hotel timezone
+func (service *hotelMgmtService) checkNoFutureStays(room *models.Room) error {
+	now, err := service.hotelTime(room.HotelID)
+	if err != nil {
+		return err
+	}
+	nights, err := service.repository.FindStayRooms(room.HotelID, now.Format(dateForm), openEndedDate)
+	if err != nil {
+		return err
+	}
+	for _, night := range nights {
+		if night.RoomID == room.ID {
+			return fmt.Errorf("%w: room %d is sold on %s", ErrRoomBooked, room.RoomNumber, night.Date[0:10])
+		}
+	}
+	return nil
+}
+
+//count rooms which are not deactivated
+func activeRoomCount(rooms []*models.Room) int {
+	count := 0
+	for _, room := range rooms {
+		if room.DeactivatedAt == nil {
+			count++
+		}
+	}
+	return count
+}
+
+//assign response model with processed data
+func roomResponse(room *models.Room) *models.RoomResponse {
+	return &models.RoomResponse{
+		RoomID:        room.ID,
+		HotelID:       room.HotelID,
+		RoomTypeID:    room.RoomTypeID,
+		RoomNumber:    room.RoomNumber,
+		RoomStatus:    roomStatus(room),
+		DeactivatedAt: room.DeactivatedAt,
+	}
+}
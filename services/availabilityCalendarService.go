@@ -0,0 +1,121 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+//longest date range of the availability calendar
+const maxAvailabilityCalendarNights = 366
+
+//function to count total, sold, blocked and out-of-order rooms of a room type with the nightly price of every date from the from date until the night before the to date
+func (service *hotelMgmtService) FindAvailabilityCalendar(hotelID int, roomTypeID int, fromDate string, toDate string) (res *models.AvailabilityCalendarResponse, err error) {
+	from, to, err := dateRange("from", fromDate, "to", toDate)
+	if err == nil && int(to.Sub(from).Hours()/24) > maxAvailabilityCalendarNights {
+		invalid := &ValidationError{}
+		invalid.Add("to", RuleMax, fmt.Sprintf("date range can't be longer than %d nights", maxAvailabilityCalendarNights))
+		err = invalid.Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err = service.repository.FindHotelByID(hotelID); err != nil {
+		return nil, err
+	}
+	roomType, err := service.repository.FindRoomTypeByID(roomTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	//counting rooms of the whole date range at once
+	totalRooms, err := service.repository.CountRooms(hotelID, roomTypeID)
+	if err != nil {
+		return nil, err
+	}
+	sold, err := service.repository.CountSoldRooms(hotelID, roomTypeID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	blocked, err := service.repository.FindBlockedRoomDates(hotelID, roomTypeID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	outOfOrder, err := service.repository.FindRoomStatusDates(hotelID, roomTypeID, models.RoomStatusOutOfOrder)
+	if err != nil {
+		return nil, err
+	}
+	prices, err := service.repository.FindPrices(hotelID, roomTypeID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	dates, _ := stayDates(fromDate, toDate)
+	soldByDate := make(map[string]int, len(sold))
+	for _, count := range sold {
+		soldByDate[count.Date[0:10]] = count.Count
+	}
+	//a room both blocked and out of order on a night is taken from sale once, as a blocked room
+	blockedByDate := make(map[string]map[int]bool, len(dates))
+	for _, block := range blocked {
+		for _, date := range dates {
+			if date >= block.FromDate[0:10] && date < block.UntilDate[0:10] {
+				if blockedByDate[date] == nil {
+					blockedByDate[date] = make(map[int]bool)
+				}
+				blockedByDate[date][block.RoomID] = true
+			}
+		}
+	}
+	//out-of-order rooms can't be sold from their status from date until the night before their status until date
+	outOfOrderByDate := make(map[string]int, len(dates))
+	for _, status := range outOfOrder {
+		for _, date := range dates {
+			if (status.FromDate == "" || status.FromDate[0:10] <= date) && (status.UntilDate == "" || status.UntilDate[0:10] > date) &&
+				!blockedByDate[date][status.RoomID] {
+				outOfOrderByDate[date]++
+			}
+		}
+	}
+	pricesByDate := make(map[string]int, len(prices))
+	for _, price := range prices {
+		pricesByDate[price.Date[0:10]] = price.Price
+	}
+
+	res = &models.AvailabilityCalendarResponse{
+		HotelID:      hotelID,
+		RoomTypeID:   roomTypeID,
+		FromDate:     fromDate,
+		ToDate:       toDate,
+		Days:         make([]*models.AvailabilityDay, 0, len(dates)),
+		MissingDates: []string{},
+	}
+	for _, date := range dates {
+		day := &models.AvailabilityDay{
+			Date:            date,
+			TotalRooms:      totalRooms,
+			SoldRooms:       soldByDate[date],
+			BlockedRooms:    len(blockedByDate[date]),
+			OutOfOrderRooms: outOfOrderByDate[date],
+		}
+		day.AvailableRooms = day.TotalRooms - day.SoldRooms - day.BlockedRooms - day.OutOfOrderRooms
+		if day.AvailableRooms < 0 {
+			day.AvailableRooms = 0
+		}
+
+		//nights without price are sold at the base rate of the room type, without base rate they can't be sold
+		price, ok := pricesByDate[date]
+		switch {
+		case ok:
+			day.Price = price
+		case roomType.BaseRate > 0:
+			day.Price = roomType.BaseRate
+			day.Fallback = true
+		default:
+			day.NoRate = true
+			res.MissingDates = append(res.MissingDates, date)
+		}
+		res.Days = append(res.Days, day)
+	}
+	return res, nil
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+func TestFindAvailabilityCalendar(t *testing.T) {
+	repo := newFakeRepo(4, "2022-12-05", 2, 100000)
+	repo.baseRate = 80000
+	//room 4 is out of order on the night of 2022-12-06 only
+	from, until := at("2022-12-06 00:00"), at("2022-12-07 00:00")
+	repo.rooms[3].RoomStatus, repo.rooms[3].StatusFromDate, repo.rooms[3].StatusUntilDate = models.RoomStatusOutOfOrder, &from, &until
+	//blocked room 3 is also out of order from 2022-12-06, it is taken from sale once per night
+	repo.rooms[2].RoomStatus, repo.rooms[2].StatusFromDate = models.RoomStatusOutOfOrder, &from
+	//stay rooms are sorted by date like the sold room counts of the repository
+	repo.stayRooms = []*models.StayRoom{{RoomID: 1, Date: "2022-12-05"}, {RoomID: 2, Date: "2022-12-05"}, {RoomID: 1, Date: "2022-12-06"}}
+	repo.roomBlocks = []*models.RoomBlock{{RoomID: 3, FromDate: "2022-12-05", UntilDate: "2022-12-07"}}
+	service := NewHotelMgmtServiceWithClock(repo, fixedClock(at("2022-12-01 10:00")))
+
+	res, err := service.FindAvailabilityCalendar(1, 1, "2022-12-05", "2022-12-08")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []models.AvailabilityDay{
+		{Date: "2022-12-05", TotalRooms: 4, SoldRooms: 2, BlockedRooms: 1, AvailableRooms: 1, Price: 100000},
+		{Date: "2022-12-06", TotalRooms: 4, SoldRooms: 1, BlockedRooms: 1, OutOfOrderRooms: 1, AvailableRooms: 1, Price: 100000},
+		{Date: "2022-12-07", TotalRooms: 4, OutOfOrderRooms: 1, AvailableRooms: 3, Price: 80000, Fallback: true},
+	}
+	if len(res.Days) != len(want) {
+		t.Fatalf("expected %d days, got %d", len(want), len(res.Days))
+	}
+	for i, day := range res.Days {
+		if *day != want[i] {
+			t.Fatalf("day %d: expected %+v, got %+v", i, want[i], *day)
+		}
+	}
+	if len(res.MissingDates) != 0 {
+		t.Fatalf("expected no missing dates, got %v", res.MissingDates)
+	}
+}
+
+func TestFindAvailabilityCalendarWithoutRate(t *testing.T) {
+	//without base rate the night after the loaded prices can't be sold
+	service := NewHotelMgmtServiceWithClock(newFakeRepo(1, "2022-12-05", 1, 100000), fixedClock(at("2022-12-01 10:00")))
+
+	res, err := service.FindAvailabilityCalendar(1, 1, "2022-12-05", "2022-12-07")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := models.AvailabilityDay{Date: "2022-12-06", TotalRooms: 1, AvailableRooms: 1, NoRate: true}
+	if *res.Days[1] != want {
+		t.Fatalf("expected %+v, got %+v", want, *res.Days[1])
+	}
+	if len(res.MissingDates) != 1 || res.MissingDates[0] != "2022-12-06" {
+		t.Fatalf("expected 2022-12-06 to be missing, got %v", res.MissingDates)
+	}
+}
+
+func TestFindAvailabilityCalendarValidation(t *testing.T) {
+	service := NewHotelMgmtServiceWithClock(newFakeRepo(1, "2022-12-05", 1, 100000), fixedClock(at("2022-12-01 10:00")))
+	for _, dates := range [][2]string{{"", "2022-12-08"}, {"2022-12-08", "2022-12-05"}, {"2022-12-01", "2024-12-01"}} {
+		if _, err := service.FindAvailabilityCalendar(1, 1, dates[0], dates[1]); !errors.Is(err, ErrValidation) {
+			t.Fatalf("dates %v: expected validation error, got %v", dates, err)
+		}
+	}
+	if _, err := service.FindAvailabilityCalendar(2, 1, "2022-12-05", "2022-12-08"); !errors.Is(err, errNotFound) {
+		t.Fatalf("expected unknown hotel to be not found, got %v", err)
+	}
+}
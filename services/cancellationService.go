@@ -0,0 +1,144 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+//ErrReservationCancelled is returned when the reservation has been cancelled before
+var ErrReservationCancelled = errors.New("reservation has already been cancelled")
+
+//policy used when neither the hotel nor the room type has a cancellation policy
+var freeCancellationPolicy = models.CancellationPolicy{
+	Name: "Free cancellation until check-in",
+}
+
+//function to find the cancellation policy shown to guests before booking
+func (service *hotelMgmtService) findCancellationPolicy(hotelID int, roomTypeID int) (*models.CancellationPolicy, error) {
+	policy, err := service.repository.FindCancellationPolicy(hotelID, roomTypeID)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		policy = &models.CancellationPolicy{}
+		*policy = freeCancellationPolicy
+		policy.HotelID = hotelID
+	}
+	return policy, nil
+}
+
+//function to cancel reservation, charging the penalty of the policy agreed on booking
+func (service *hotelMgmtService) CancelReservation(id int) (res *models.CancellationResponse, err error) {
+	reservation, err := service.repository.FindReservationByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if reservation.Order.OrderStatus.Status == models.OrderStatusCancelled {
+		return nil, ErrReservationCancelled
+	}
+	if err = checkOrderTransition(reservation.Order.OrderStatus.Status, models.OrderStatusCancelled); err != nil {
+		return nil, err
+	}
+
+	policy, err := service.agreedCancellationPolicy(reservation)
+	if err != nil {
+		return nil, err
+	}
+
+	dates, err := stayDates(reservation.CheckinDate[0:10], reservation.CheckoutDate[0:10])
+	if err != nil {
+		return nil, err
+	}
+	now, err := service.hotelTime(reservation.HotelID)
+	if err != nil {
+		return nil, err
+	}
+	checkin, _ := time.Parse(dateForm, dates[0])
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	daysBeforeCheckin := int(checkin.Sub(today).Hours() / 24)
+
+	finalPrice := reservation.Order.FinalPrice
+	firstNightPrice := reservation.Order.FirstNightPrice
+	if firstNightPrice == 0 {
+		//orders booked before the first night price was saved are charged the average nightly price
+		firstNightPrice = finalPrice / len(dates)
+	}
+	fee := cancellationFee(policy, finalPrice, firstNightPrice, daysBeforeCheckin)
+	if err = service.repository.CancelReservation(reservation, models.OrderStatusCancelled, fee); err != nil {
+		return nil, err
+	}
+
+	//assign response model with processed data
+	res = &models.CancellationResponse{
+		ReservationID:      reservation.ID,
+		OrderID:            reservation.OrderID,
+		OrderStatus:        reservation.Order.OrderStatus.Status,
+		CheckinDate:        dates[0],
+		CheckoutDate:       reservation.CheckoutDate[0:10],
+		FinalPrice:         finalPrice,
+		CancellationFee:    fee,
+		RefundAmount:       finalPrice - fee,
+		CancellationPolicy: policy,
+	}
+	return res, nil
+}
+
+//price of the first night of every room, the rooms of a booking share the nightly prices
+func firstNightPrice(rooms []*models.Room, roomQty int) int {
+	if len(rooms) == 0 || len(rooms[0].Price) == 0 {
+		return 0
+	}
+	return rooms[0].Price[0].Price * roomQty
+}
+
+//copy the terms of the cancellation policy onto the reservation being booked
+func agreeCancellationPolicy(reservation *models.Reservation, policy *models.CancellationPolicy) {
+	reservation.CancellationPolicyID = policy.ID
+	reservation.CancellationTermsSaved = true
+	reservation.CancellationPolicyName = policy.Name
+	reservation.FreeCancellationDays = policy.FreeCancellationDays
+	reservation.CancellationPenalty = policy.Penalty
+	reservation.NonRefundable = policy.NonRefundable
+}
+
+//function to find the cancellation policy agreed on booking, the current policy of the hotel never applies to a booked reservation
+func (service *hotelMgmtService) agreedCancellationPolicy(reservation *models.Reservation) (*models.CancellationPolicy, error) {
+	if reservation.CancellationTermsSaved {
+		return &models.CancellationPolicy{
+			ID:                   reservation.CancellationPolicyID,
+			HotelID:              reservation.HotelID,
+			Name:                 reservation.CancellationPolicyName,
+			FreeCancellationDays: reservation.FreeCancellationDays,
+			Penalty:              reservation.CancellationPenalty,
+			NonRefundable:        reservation.NonRefundable,
+		}, nil
+	}
+
+	//reservations booked before the terms were copied keep the stored policy, or free cancellation when they had none
+	if reservation.CancellationPolicyID != 0 {
+		return service.repository.FindCancellationPolicyByID(reservation.CancellationPolicyID)
+	}
+	policy := &models.CancellationPolicy{}
+	*policy = freeCancellationPolicy
+	policy.HotelID = reservation.HotelID
+	return policy, nil
+}
+
+//penalty of cancelling the given days before check-in, first night is charged at the price paid for it
+func cancellationFee(policy *models.CancellationPolicy, finalPrice int, firstNightPrice int, daysBeforeCheckin int) int {
+	if policy.NonRefundable {
+		return finalPrice
+	}
+	if daysBeforeCheckin >= policy.FreeCancellationDays {
+		return 0
+	}
+	switch policy.Penalty {
+	case models.PenaltyFirstNight:
+		return firstNightPrice
+	case models.PenaltyFullStay:
+		return finalPrice
+	}
+	return 0
+}
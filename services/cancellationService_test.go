@@ -0,0 +1,79 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+func TestCancellationFee(t *testing.T) {
+	flexible := &models.CancellationPolicy{FreeCancellationDays: 3, Penalty: models.PenaltyFirstNight}
+	strict := &models.CancellationPolicy{FreeCancellationDays: 7, Penalty: models.PenaltyFullStay}
+	nonRefundable := &models.CancellationPolicy{NonRefundable: true}
+
+	tests := []struct {
+		name              string
+		policy            *models.CancellationPolicy
+		daysBeforeCheckin int
+		want              int
+	}{
+		{"free before deadline", flexible, 5, 0},
+		{"free on deadline", flexible, 3, 0},
+		{"first night after deadline", flexible, 2, 100000},
+		{"first night after checkin", flexible, -1, 100000},
+		{"full stay after deadline", strict, 6, 300000},
+		{"non refundable", nonRefundable, 30, 300000},
+		{"default policy on checkin day", &freeCancellationPolicy, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cancellationFee(tt.policy, 300000, 100000, tt.daysBeforeCheckin); got != tt.want {
+				t.Fatalf("expected fee %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCancelReservationKeepsAgreedPolicy(t *testing.T) {
+	repo := newFakeRepo(2, "2022-12-06", 2, 100000)
+	service := NewHotelMgmtServiceWithClock(repo, fixedClock(at("2022-12-05 10:00")))
+	book := func() int {
+		res, err := service.CreateReservation(&models.ReservationRequest{CustomerName: "guest", HotelID: 1, RoomQty: 1, RoomTypeID: 1, CheckinDate: "2022-12-06", CheckoutDate: "2022-12-08"})
+		if err != nil {
+			t.Fatalf("create reservation: %v", err)
+		}
+		return res.ReservationID
+	}
+
+	//booked under free cancellation, the hotel turns non refundable afterwards
+	freeID := book()
+	repo.policy = &models.CancellationPolicy{ID: 1, HotelID: 1, FreeCancellationDays: 3, Penalty: models.PenaltyFirstNight}
+	flexibleID := book()
+	repo.policy.NonRefundable = true
+
+	res, err := service.CancelReservation(freeID)
+	if err != nil || res.CancellationFee != 0 || res.CancellationPolicy.Name != freeCancellationPolicy.Name {
+		t.Fatalf("expected free cancellation, got %+v (%v)", res, err)
+	}
+	res, err = service.CancelReservation(flexibleID)
+	if err != nil || res.CancellationFee != 100000 || res.CancellationPolicy.NonRefundable {
+		t.Fatalf("expected first night penalty of the agreed policy, got %+v (%v)", res, err)
+	}
+}
+
+func TestCancelReservationChargesFirstNightPrice(t *testing.T) {
+	//the first night is sold in high season, the average nightly price would be 125000
+	repo := newFakeRepo(2, "2022-12-06", 2, 100000)
+	repo.prices[0].Price = 150000
+	repo.policy = &models.CancellationPolicy{ID: 1, HotelID: 1, FreeCancellationDays: 3, Penalty: models.PenaltyFirstNight}
+	service := NewHotelMgmtServiceWithClock(repo, fixedClock(at("2022-12-05 10:00")))
+
+	booked, err := service.CreateReservation(&models.ReservationRequest{CustomerName: "guest", HotelID: 1, RoomQty: 2, RoomTypeID: 1, CheckinDate: "2022-12-06", CheckoutDate: "2022-12-08"})
+	if err != nil {
+		t.Fatalf("create reservation: %v", err)
+	}
+	res, err := service.CancelReservation(booked.ReservationID)
+	if err != nil || res.CancellationFee != 300000 || res.RefundAmount != 200000 {
+		t.Fatalf("expected first night of both rooms charged, got %+v (%v)", res, err)
+	}
+}
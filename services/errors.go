@@ -0,0 +1,71 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+	"github.com/nurcholisnanda/hotel-management-system/repositories"
+)
+
+var (
+	//ErrNotFound is returned when a hotel, room, reservation or any other record doesn't exist
+	ErrNotFound = repositories.ErrNotFound
+	//ErrNoAvailability is returned when fewer rooms than requested are free for the stay
+	ErrNoAvailability = errors.New("Total available rooms exceeded your requirement.")
+	//ErrPromoNotFound is returned when a requested promo code or promo id doesn't exist
+	ErrPromoNotFound = errors.New("promo not found")
+	//ErrPromoIneligible is returned when a promo can't be used for the request
+	ErrPromoIneligible = errors.New("promo can't be used for your request")
+	//ErrInternal is returned when stored data is broken, it is never caused by the request
+	ErrInternal = errors.New("internal error")
+)
+
+//promo rules reported when a promo can't be used
+const (
+	PromoRuleUnavailable   = "unavailable"
+	PromoRuleStacking      = "stacking"
+	PromoRuleMinimumNights = "minimum_nights"
+	PromoRuleMinimumRooms  = "minimum_rooms"
+	PromoRuleBookingDay    = "booking_day"
+	PromoRuleBookingHours  = "booking_hours"
+	PromoRuleBookingWindow = "booking_window"
+	PromoRuleStayDays      = "stay_days"
+)
+
+//PromoIneligibleError lists every rule of the promo broken by the request, it matches ErrPromoIneligible
+type PromoIneligibleError struct {
+	PromoID     int
+	FailedRules []*models.PromoRuleFailure
+}
+
+func (err *PromoIneligibleError) Error() string {
+	messages := make([]string, 0, len(err.FailedRules))
+	for _, failure := range err.FailedRules {
+		messages = append(messages, failure.Message)
+	}
+	return fmt.Sprintf("sorry, promo %d can't be used for your request: %s", err.PromoID, strings.Join(messages, "; "))
+}
+
+func (err *PromoIneligibleError) Is(target error) bool {
+	return target == ErrPromoIneligible
+}
+
+//Add records a broken promo rule with a hint to qualify, the hint may be empty
+func (err *PromoIneligibleError) Add(rule string, message string, hint string) {
+	err.FailedRules = append(err.FailedRules, &models.PromoRuleFailure{Rule: rule, Message: message, Hint: hint})
+}
+
+//AddCount records a broken minimum rule with what the request has and what the promo needs
+func (err *PromoIneligibleError) AddCount(rule string, message string, have int, need int, hint string) {
+	err.FailedRules = append(err.FailedRules, &models.PromoRuleFailure{Rule: rule, Message: message, Have: have, Need: need, Hint: hint})
+}
+
+//Err returns the ineligible error when a rule is broken, nil otherwise
+func (err *PromoIneligibleError) Err() error {
+	if len(err.FailedRules) == 0 {
+		return nil
+	}
+	return err
+}
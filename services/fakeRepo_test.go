@@ -0,0 +1,254 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+	"github.com/nurcholisnanda/hotel-management-system/repositories"
+)
+
+var errNotFound = repositories.ErrNotFound
+
+//fakeRepo keeps one hotel in memory, methods not overridden here panic through the nil embedded interface
+type fakeRepo struct {
+	repositories.HotelMgmtRepo
+	hotel         *models.Hotel
+	rooms         []*models.Room
+	prices        []*models.Price
+	baseRate      int
+	bookedRoomIDs []int
+	stayRooms     []*models.StayRoom
+	roomBlocks    []*models.RoomBlock
+	policy        *models.CancellationPolicy
+	reservations  []*models.Reservation
+	promos        []*models.Promo
+	stayPromos    map[int]*models.StayDayPromo
+	bookingPromos map[int]*models.BookingDayPromo
+	promoErr      error
+}
+
+func newFakeRepo(roomCount int, checkinDate string, nights int, nightPrice int) *fakeRepo {
+	repo := &fakeRepo{
+		hotel:         &models.Hotel{ID: 1, HotelName: "Test Hotel", Timezone: "UTC"},
+		stayPromos:    make(map[int]*models.StayDayPromo),
+		bookingPromos: make(map[int]*models.BookingDayPromo),
+	}
+	for i := 1; i <= roomCount; i++ {
+		repo.rooms = append(repo.rooms, &models.Room{ID: i, HotelID: 1, RoomTypeID: 1, RoomNumber: 100 + i})
+	}
+	day, _ := time.Parse(dateForm, checkinDate)
+	for i := 0; i < nights; i++ {
+		repo.prices = append(repo.prices, &models.Price{
+			Date:       day.AddDate(0, 0, i).Format(dateForm),
+			HotelID:    1,
+			RoomTypeID: 1,
+			Price:      nightPrice,
+		})
+	}
+	return repo
+}
+
+func (repo *fakeRepo) FindBookedRoomIDs(hotelID int, fromDate string, toDate string) ([]int, error) {
+	return repo.bookedRoomIDs, nil
+}
+
+func (repo *fakeRepo) FindRooms(hotelID int, roomTypeID int, excludeRoomIDs []int) ([]*models.Room, error) {
+	booked := make(map[int]bool)
+	for _, id := range excludeRoomIDs {
+		booked[id] = true
+	}
+	var rooms []*models.Room
+	for _, room := range repo.rooms {
+		if !booked[room.ID] {
+			copied := *room
+			rooms = append(rooms, &copied)
+		}
+	}
+	return rooms, nil
+}
+
+func (repo *fakeRepo) FindPrices(hotelID int, roomTypeID int, fromDate string, toDate string) ([]*models.Price, error) {
+	prices := make([]*models.Price, 0, len(repo.prices))
+	for _, price := range repo.prices {
+		copied := *price
+		prices = append(prices, &copied)
+	}
+	return prices, nil
+}
+
+func (repo *fakeRepo) FindHotelByID(id int) (*models.Hotel, error) {
+	if repo.hotel == nil || repo.hotel.ID != id {
+		return nil, errNotFound
+	}
+	return repo.hotel, nil
+}
+
+func (repo *fakeRepo) FindPromos() ([]*models.Promo, error) {
+	return repo.promos, nil
+}
+
+func (repo *fakeRepo) FindPromoByID(id int) (*models.Promo, error) {
+	for _, promo := range repo.promos {
+		if promo.ID == id {
+			return promo, nil
+		}
+	}
+	return nil, errNotFound
+}
+
+func (repo *fakeRepo) FindPromoByCode(code string) (*models.Promo, error) {
+	for _, promo := range repo.promos {
+		if promo.Code != nil && *promo.Code == code {
+			return promo, nil
+		}
+	}
+	return nil, errNotFound
+}
+
+func (repo *fakeRepo) FindStayPromoByID(id int) (*models.StayDayPromo, error) {
+	if repo.promoErr != nil {
+		return nil, repo.promoErr
+	}
+	if promo, ok := repo.stayPromos[id]; ok {
+		return promo, nil
+	}
+	return nil, errNotFound
+}
+
+func (repo *fakeRepo) FindBookingPromoByID(id int) (*models.BookingDayPromo, error) {
+	if promo, ok := repo.bookingPromos[id]; ok {
+		return promo, nil
+	}
+	return nil, errNotFound
+}
+
+func (repo *fakeRepo) FindCancellationPolicy(hotelID int, roomTypeID int) (*models.CancellationPolicy, error) {
+	if repo.policy == nil {
+		return nil, nil
+	}
+	copied := *repo.policy
+	return &copied, nil
+}
+
+func (repo *fakeRepo) FindCancellationPolicyByID(id int) (*models.CancellationPolicy, error) {
+	if repo.policy == nil || repo.policy.ID != id {
+		return nil, errNotFound
+	}
+	copied := *repo.policy
+	return &copied, nil
+}
+
+func (repo *fakeRepo) CreateReservation(reservation *models.Reservation, status string, roomIDs []int, dates []string) error {
+	reservation.ID = len(repo.reservations) + 1
+	reservation.OrderID = reservation.ID
+	reservation.Order.OrderStatus = models.OrderStatus{Status: status}
+	copied := *reservation
+	repo.reservations = append(repo.reservations, &copied)
+	return nil
+}
+
+func (repo *fakeRepo) FindReservationByID(id int) (*models.Reservation, error) {
+	if id < 1 || id > len(repo.reservations) {
+		return nil, errNotFound
+	}
+	copied := *repo.reservations[id-1]
+	return &copied, nil
+}
+
+func (repo *fakeRepo) CancelReservation(reservation *models.Reservation, status string, cancellationFee int) error {
+	stored := repo.reservations[reservation.ID-1]
+	stored.Order.OrderStatus = models.OrderStatus{Status: status}
+	stored.Order.CancellationFee = cancellationFee
+	reservation.Order = stored.Order
+	return nil
+}
+
+func (repo *fakeRepo) FindRoomBlocks(hotelID int, fromDate string, toDate string) ([]*models.RoomBlock, error) {
+	return repo.roomBlocks, nil
+}
+
+func (repo *fakeRepo) FindStayRooms(hotelID int, fromDate string, toDate string) ([]*models.StayRoom, error) {
+	var nights []*models.StayRoom
+	for _, night := range repo.stayRooms {
+		if night.Date >= fromDate && night.Date < toDate {
+			nights = append(nights, night)
+		}
+	}
+	return nights, nil
+}
+
+func (repo *fakeRepo) CountRooms(hotelID int, roomTypeID int) (int, error) {
+	return len(repo.rooms), nil
+}
+
+func (repo *fakeRepo) CountSoldRooms(hotelID int, roomTypeID int, fromDate string, toDate string) ([]*models.DateCount, error) {
+	var counts []*models.DateCount
+	for _, night := range repo.stayRooms {
+		if night.Date < fromDate || night.Date >= toDate {
+			continue
+		}
+		if len(counts) > 0 && counts[len(counts)-1].Date == night.Date {
+			counts[len(counts)-1].Count++
+		} else {
+			counts = append(counts, &models.DateCount{Date: night.Date, Count: 1})
+		}
+	}
+	return counts, nil
+}
+
+func (repo *fakeRepo) FindBlockedRoomDates(hotelID int, roomTypeID int, fromDate string, toDate string) ([]*models.RoomDateRange, error) {
+	var ranges []*models.RoomDateRange
+	for _, block := range repo.roomBlocks {
+		ranges = append(ranges, &models.RoomDateRange{RoomID: block.RoomID, FromDate: block.FromDate, UntilDate: block.UntilDate})
+	}
+	return ranges, nil
+}
+
+func (repo *fakeRepo) FindRoomStatusDates(hotelID int, roomTypeID int, status string) ([]*models.RoomDateRange, error) {
+	var ranges []*models.RoomDateRange
+	for _, room := range repo.rooms {
+		if room.RoomStatus != status {
+			continue
+		}
+		dates := &models.RoomDateRange{RoomID: room.ID}
+		if room.StatusFromDate != nil {
+			dates.FromDate = room.StatusFromDate.Format(dateForm)
+		}
+		if room.StatusUntilDate != nil {
+			dates.UntilDate = room.StatusUntilDate.Format(dateForm)
+		}
+		ranges = append(ranges, dates)
+	}
+	return ranges, nil
+}
+
+//fixedClock always tells the same time
+type fixedClock time.Time
+
+func (clock fixedClock) Now() time.Time {
+	return time.Time(clock)
+}
+
+func (repo *fakeRepo) FindRoomTypeByID(id int) (*models.RoomType, error) {
+	if id != 1 {
+		return nil, errNotFound
+	}
+	return &models.RoomType{ID: 1, Name: "Deluxe", BaseRate: repo.baseRate}, nil
+}
+
+func (repo *fakeRepo) SavePrices(hotelID int, roomTypeID int, prices []*models.Price) error {
+	replaced := make(map[string]bool, len(prices))
+	for _, price := range prices {
+		replaced[price.Date] = true
+	}
+	kept := repo.prices[:0]
+	for _, price := range repo.prices {
+		if !replaced[price.Date] {
+			kept = append(kept, price)
+		}
+	}
+	repo.prices = append(kept, prices...)
+	sort.Slice(repo.prices, func(i, j int) bool { return repo.prices[i].Date < repo.prices[j].Date })
+	return nil
+}
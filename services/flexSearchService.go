@@ -0,0 +1,184 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+//longest date window of the flexible date search
+const maxFlexWindowNights = 92
+
+//function to list every stay of the given nights inside the date window from the cheapest, sold nights, blocks and prices of the window are fetched once
+func (service *hotelMgmtService) FindFlexibleStays(hotelID int, roomTypeID int, fromDate string, toDate string, nights int, roomQty int) (res *models.FlexStaysResponse, err error) {
+	hotel, from, err := service.validateFlexSearch(hotelID, fromDate, toDate, nights, roomQty)
+	if err != nil {
+		return nil, err
+	}
+	roomType, err := service.repository.FindRoomTypeByID(roomTypeID)
+	if err != nil {
+		return nil, err
+	}
+	now, err := service.hotelTime(hotelID)
+	if err != nil {
+		return nil, err
+	}
+
+	//fetching rooms, sold nights, blocks and nightly prices of the whole window
+	rooms, err := service.repository.FindRooms(hotelID, roomTypeID, nil)
+	if err != nil {
+		return nil, err
+	}
+	sold, err := service.repository.FindStayRooms(hotelID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := service.repository.FindRoomBlocks(hotelID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	prices, err := service.repository.FindPrices(hotelID, roomTypeID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	//rooms which can't be sold on each night of the window
+	dates, _ := stayDates(fromDate, toDate)
+	taken := make(map[string]map[int]bool, len(dates))
+	for _, date := range dates {
+		taken[date] = make(map[int]bool)
+	}
+	for _, night := range sold {
+		if nightTaken, ok := taken[night.Date[0:10]]; ok {
+			nightTaken[night.RoomID] = true
+		}
+	}
+	for _, block := range blocks {
+		for _, date := range dates {
+			if date >= block.FromDate[0:10] && date < block.UntilDate[0:10] {
+				taken[date][block.RoomID] = true
+			}
+		}
+	}
+	for _, room := range rooms {
+		for i, date := range dates {
+			if isRoomOutOfOrder(room, models.RoomStatusOutOfOrder, date, from.AddDate(0, 0, i+1).Format(dateForm)) {
+				taken[date][room.ID] = true
+			}
+		}
+	}
+	pricesByDate := make(map[string]int, len(prices))
+	for _, price := range prices {
+		pricesByDate[price.Date[0:10]] = price.Price
+	}
+
+	res = &models.FlexStaysResponse{
+		HotelID:    hotelID,
+		RoomTypeID: roomTypeID,
+		RoomQty:    roomQty,
+		Nights:     nights,
+		FromDate:   fromDate,
+		ToDate:     toDate,
+		Stays:      []*models.FlexStay{},
+		Calendar:   []*models.FlexStay{},
+	}
+	today, _ := time.Parse(dateForm, now.Format(dateForm))
+	for checkin := 0; checkin+nights <= len(dates); checkin++ {
+		stayNights := dates[checkin : checkin+nights]
+		stay := &models.FlexStay{
+			CheckinDate:  stayNights[0],
+			CheckoutDate: from.AddDate(0, 0, checkin+nights).Format(dateForm),
+		}
+		res.Calendar = append(res.Calendar, stay)
+		if lastCheckin := today.AddDate(0, 0, hotel.BookingHorizon); hotel.BookingHorizon > 0 && stayNights[0] > lastCheckin.Format(dateForm) {
+			stay.Status = models.FlexStayClosed
+			continue
+		}
+
+		//a room counts when it is free on every night of the stay
+		for _, room := range rooms {
+			free := true
+			for _, date := range stayNights {
+				free = free && !taken[date][room.ID]
+			}
+			if free {
+				stay.AvailableRoomCount++
+			}
+		}
+		if stay.AvailableRoomCount < roomQty {
+			stay.Status = models.FlexStaySoldOut
+			continue
+		}
+
+		//nights without price take the base rate of the room type
+		totalPrice, priced := 0, true
+		for _, date := range stayNights {
+			price, ok := pricesByDate[date]
+			if !ok {
+				price = roomType.BaseRate
+				priced = priced && price > 0
+			}
+			totalPrice = totalPrice + price*roomQty
+		}
+		if !priced {
+			stay.Status = models.FlexStayNoRate
+			continue
+		}
+		stay.Status = models.FlexStayAvailable
+		stay.TotalPrice = totalPrice
+		res.Stays = append(res.Stays, stay)
+	}
+
+	//cheapest stay first, earlier check-in first on the same price
+	sort.SliceStable(res.Stays, func(i, j int) bool {
+		return res.Stays[i].TotalPrice < res.Stays[j].TotalPrice
+	})
+	return res, nil
+}
+
+//check the window, nights and room qty of a flexible date search against the stay rules of the hotel
+func (service *hotelMgmtService) validateFlexSearch(hotelID int, fromDate string, toDate string, nights int, roomQty int) (hotel *models.Hotel, from time.Time, err error) {
+	from, to, err := dateRange("from_date", fromDate, "to_date", toDate)
+	invalid, ok := err.(*ValidationError)
+	if !ok {
+		invalid = &ValidationError{}
+	}
+	if nights < 1 {
+		invalid.Add("nights", RuleMin, "nights must be at least 1")
+	}
+	if roomQty < 1 {
+		invalid.Add("room_qty", RuleMin, "room_qty must be at least 1")
+	}
+	if err = invalid.Err(); err != nil {
+		return nil, from, err
+	}
+
+	hotel, err = service.repository.FindHotelByID(hotelID)
+	if err != nil {
+		return nil, from, err
+	}
+	now, err := service.hotelTime(hotelID)
+	if err != nil {
+		return nil, from, err
+	}
+	today, _ := time.Parse(dateForm, now.Format(dateForm))
+	windowNights := int(to.Sub(from).Hours() / 24)
+	if from.Before(today) {
+		invalid.Add("from_date", RuleNotPast, fmt.Sprintf("from_date can't be before %s, today in hotel timezone", today.Format(dateForm)))
+	}
+	if windowNights > maxFlexWindowNights {
+		invalid.Add("to_date", RuleMax, fmt.Sprintf("date window can't be longer than %d nights", maxFlexWindowNights))
+	}
+	if windowNights < nights {
+		invalid.Add("nights", RuleMax, fmt.Sprintf("nights can't be more than the %d nights of the date window", windowNights))
+	}
+	if hotel.MinStayNights > 0 && nights < hotel.MinStayNights {
+		invalid.Add("nights", RuleMinStay, fmt.Sprintf("stay must be at least %d nights", hotel.MinStayNights))
+	}
+	if hotel.MaxStayNights > 0 && nights > hotel.MaxStayNights {
+		invalid.Add("nights", RuleMaxStay, fmt.Sprintf("stay can't be longer than %d nights", hotel.MaxStayNights))
+	}
+	return hotel, from, invalid.Err()
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+func TestFindFlexibleStays(t *testing.T) {
+	repo := newFakeRepo(2, "2022-12-05", 0, 0)
+	for date, price := range map[string]int{"2022-12-05": 100000, "2022-12-06": 300000, "2022-12-07": 100000, "2022-12-08": 100000, "2022-12-10": 50000} {
+		repo.prices = append(repo.prices, &models.Price{Date: date, HotelID: 1, RoomTypeID: 1, Price: price})
+	}
+	//room 1 is sold and room 2 is blocked on the night of 2022-12-07
+	repo.stayRooms = []*models.StayRoom{{RoomID: 1, Date: "2022-12-07"}}
+	repo.roomBlocks = []*models.RoomBlock{{RoomID: 2, FromDate: "2022-12-07", UntilDate: "2022-12-08"}}
+	service := NewHotelMgmtServiceWithClock(repo, fixedClock(at("2022-12-05 10:00")))
+
+	res, err := service.FindFlexibleStays(1, 1, "2022-12-05", "2022-12-11", 2, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantCalendar := []string{models.FlexStayAvailable, models.FlexStaySoldOut, models.FlexStaySoldOut, models.FlexStayNoRate, models.FlexStayNoRate}
+	if len(res.Calendar) != len(wantCalendar) {
+		t.Fatalf("expected %d check-in dates, got %+v", len(wantCalendar), res.Calendar)
+	}
+	for i, stay := range res.Calendar {
+		if stay.Status != wantCalendar[i] {
+			t.Fatalf("check-in %s: expected %s, got %s", stay.CheckinDate, wantCalendar[i], stay.Status)
+		}
+	}
+	if len(res.Stays) != 1 || res.Stays[0].TotalPrice != 400000 || res.Stays[0].AvailableRoomCount != 2 {
+		t.Fatalf("unexpected stays %+v", res.Stays)
+	}
+
+	//with a base rate the unpriced night can be sold, cheapest stay comes first
+	repo.baseRate = 80000
+	res, err = service.FindFlexibleStays(1, 1, "2022-12-05", "2022-12-11", 2, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantStays := []string{"2022-12-09", "2022-12-08", "2022-12-05"}
+	if len(res.Stays) != len(wantStays) {
+		t.Fatalf("expected %d stays, got %+v", len(wantStays), res.Stays)
+	}
+	for i, stay := range res.Stays {
+		if stay.CheckinDate != wantStays[i] {
+			t.Fatalf("stay %d: expected check-in %s, got %s", i, wantStays[i], stay.CheckinDate)
+		}
+	}
+}
+
+func TestFindFlexibleStaysValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		fromDate string
+		toDate   string
+		nights   int
+		field    string
+		rule     string
+	}{
+		{"window shorter than stay", "2022-12-05", "2022-12-07", 3, "nights", RuleMax},
+		{"window in the past", "2022-12-01", "2022-12-10", 2, "from_date", RuleNotPast},
+		{"window too long", "2022-12-05", "2023-06-01", 2, "to_date", RuleMax},
+		{"reversed window", "2022-12-10", "2022-12-05", 2, "to_date", RuleAfter},
+		{"no nights", "2022-12-05", "2022-12-10", 0, "nights", RuleMin},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewHotelMgmtServiceWithClock(newFakeRepo(1, "2022-12-05", 0, 0), fixedClock(at("2022-12-05 10:00")))
+			_, err := service.FindFlexibleStays(1, 1, tt.fromDate, tt.toDate, tt.nights, 1)
+			var invalid *ValidationError
+			if !errors.As(err, &invalid) || len(invalid.Fields) != 1 || invalid.Fields[0].Field != tt.field || invalid.Fields[0].Rule != tt.rule {
+				t.Fatalf("expected %s to break %s, got %v", tt.field, tt.rule, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+//ErrOutsideStay is returned when the front desk action doesn't fit the dates of the stay
+var ErrOutsideStay = errors.New("reservation is not staying today")
+
+//function to check in guests of a reservation, each guest gets one of the booked rooms
+func (service *hotelMgmtService) CheckInReservation(id int, req *models.CheckInRequest) (res *models.FrontDeskResponse, err error) {
+	reservation, err := service.repository.FindReservationByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkOrderTransition(reservation.Order.OrderStatus.Status, models.OrderStatusCheckedIn); err != nil {
+		return nil, err
+	}
+
+	//guests can arrive from the checkin date until the day before checkout
+	now, err := service.hotelTime(reservation.HotelID)
+	if err != nil {
+		return nil, err
+	}
+	today := now.Format(dateForm)
+	checkinDate, checkoutDate := reservation.CheckinDate[0:10], reservation.CheckoutDate[0:10]
+	if today < checkinDate || today >= checkoutDate {
+		return nil, fmt.Errorf("%w: check-in is open from %s until %s", ErrOutsideStay, checkinDate, checkoutDate)
+	}
+
+	stays, err := service.repository.FindReservationStays(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Guests) > len(stays) {
+		invalid := &ValidationError{}
+		invalid.Add("guests", RuleMax, fmt.Sprintf("reservation has %d rooms for %d guests", len(stays), len(req.Guests)))
+		return nil, invalid
+	}
+
+	//guests asking for a room number are assigned first, the others take the remaining rooms in booking order
+	assigned := make(map[int]bool, len(stays))
+	for _, guest := range req.Guests {
+		if guest.RoomNumber == 0 {
+			continue
+		}
+		stay := findStayByRoomNumber(stays, assigned, guest.RoomNumber)
+		if stay == nil {
+			invalid := &ValidationError{}
+			invalid.Add("room_number", RuleBooked, fmt.Sprintf("room %d is not booked by this reservation", guest.RoomNumber))
+			return nil, invalid
+		}
+		stay.GuestName = guest.GuestName
+		assigned[stay.ID] = true
+	}
+	for _, guest := range req.Guests {
+		if guest.RoomNumber != 0 {
+			continue
+		}
+		for _, stay := range stays {
+			if !assigned[stay.ID] {
+				stay.GuestName = guest.GuestName
+				assigned[stay.ID] = true
+				break
+			}
+		}
+	}
+	for _, stay := range stays {
+		stay.CheckedInAt = &now
+	}
+
+	if err = service.repository.CheckInReservation(reservation, stays); err != nil {
+		return nil, err
+	}
+	return frontDeskResponse(reservation, stays, nil), nil
+}
+
+//function to check out a reservation, nights after today go back to inventory when guests leave early,
+//the final price of the whole booked stay is kept and the released nights are not refunded
+func (service *hotelMgmtService) CheckOutReservation(id int) (res *models.FrontDeskResponse, err error) {
+	reservation, err := service.repository.FindReservationByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkOrderTransition(reservation.Order.OrderStatus.Status, models.OrderStatusCheckedOut); err != nil {
+		return nil, err
+	}
+
+	now, err := service.hotelTime(reservation.HotelID)
+	if err != nil {
+		return nil, err
+	}
+	stays, err := service.repository.FindReservationStays(id)
+	if err != nil {
+		return nil, err
+	}
+	held, err := service.repository.FindReservationStayRooms(id)
+	if err != nil {
+		return nil, err
+	}
+
+	//the first night is used even when guests leave on their arrival day
+	checkin, err := time.Parse(dateForm, reservation.CheckinDate[0:10])
+	if err != nil {
+		return nil, err
+	}
+	releaseFromDate := now.Format(dateForm)
+	if secondNight := checkin.AddDate(0, 0, 1).Format(dateForm); releaseFromDate < secondNight {
+		releaseFromDate = secondNight
+	}
+	var releaseNights []*models.StayRoom
+	for _, night := range held {
+		if night.Date[0:10] >= releaseFromDate {
+			releaseNights = append(releaseNights, night)
+		}
+	}
+	for _, stay := range stays {
+		stay.CheckedOutAt = &now
+	}
+
+	if err = service.repository.CheckOutReservation(reservation, stays, releaseFromDate); err != nil {
+		return nil, err
+	}
+	return frontDeskResponse(reservation, stays, roomNights(releaseNights)), nil
+}
+
+//find stay of a room not assigned to a guest yet
+func findStayByRoomNumber(stays []*models.Stay, assigned map[int]bool, roomNumber int) *models.Stay {
+	for _, stay := range stays {
+		if stay.Room.RoomNumber == roomNumber && !assigned[stay.ID] {
+			return stay
+		}
+	}
+	return nil
+}
+
+//assign response model with processed data
+func frontDeskResponse(reservation *models.Reservation, stays []*models.Stay, releasedNights []*models.RoomNight) *models.FrontDeskResponse {
+	res := &models.FrontDeskResponse{
+		ReservationID:  reservation.ID,
+		OrderID:        reservation.OrderID,
+		OrderStatus:    reservation.Order.OrderStatus.Status,
+		CheckinDate:    reservation.CheckinDate[0:10],
+		CheckoutDate:   reservation.CheckoutDate[0:10],
+		FinalPrice:     reservation.Order.FinalPrice,
+		ReleasedNights: releasedNights,
+	}
+	for _, stay := range stays {
+		res.Stays = append(res.Stays, &models.StayResponse{
+			StayID:       stay.ID,
+			RoomID:       stay.RoomID,
+			RoomNumber:   stay.Room.RoomNumber,
+			GuestName:    stay.GuestName,
+			CheckedInAt:  stay.CheckedInAt,
+			CheckedOutAt: stay.CheckedOutAt,
+		})
+	}
+	return res
+}
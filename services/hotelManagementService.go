@@ -2,6 +2,10 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -10,60 +14,125 @@ import (
 )
 
 type HotelMgmtService interface {
-	FindAvailableRooms(checkinDate string, checkoutDate string, roomQty int, roomTypeID int) (availableRooms *models.HotelAvailableRoomsResponse, err error)
+	FindHotels() (hotels []*models.Hotel, err error)
+	FindAvailableRooms(hotelID int, checkinDate string, checkoutDate string, roomQty int, roomTypeID int) (availableRooms *models.HotelAvailableRoomsResponse, err error)
 	FindPromoRooms(req *models.PromoRoomsRequest) (res *models.PromoRoomsResponse, err error)
+	FindBestPromoRooms(hotelID int, checkinDate string, checkoutDate string, roomQty int, roomTypeID int) (res *models.BestPromoRoomsResponse, err error)
+	FindFlexibleStays(hotelID int, roomTypeID int, fromDate string, toDate string, nights int, roomQty int) (res *models.FlexStaysResponse, err error)
+	FindMultiRoomAvailability(req *models.MultiRoomSearchRequest) (res *models.MultiRoomSearchResponse, err error)
+	FindAvailabilityCalendar(hotelID int, roomTypeID int, fromDate string, toDate string) (res *models.AvailabilityCalendarResponse, err error)
+	CreateReservation(req *models.ReservationRequest) (res *models.ReservationResponse, err error)
+	CreateMultiRoomReservation(req *models.MultiRoomReservationRequest) (res *models.MultiRoomReservationResponse, err error)
+	UpdateReservation(id int, req *models.ReservationUpdateRequest) (res *models.ReservationUpdateResponse, err error)
+	CancelReservation(id int) (res *models.CancellationResponse, err error)
+	UpdateRoomStatus(roomID int, req *models.RoomStatusRequest) (room *models.Room, err error)
+	FindHousekeepingBoard(hotelID int, date string) (res *models.HousekeepingBoardResponse, err error)
+	FindRoomBlocks(hotelID int, fromDate string, toDate string) (blocks []*models.RoomBlock, err error)
+	FindRoomBlock(id int) (block *models.RoomBlock, err error)
+	CreateRoomBlock(req *models.RoomBlockRequest) (block *models.RoomBlock, err error)
+	UpdateRoomBlock(id int, req *models.RoomBlockRequest) (block *models.RoomBlock, err error)
+	DeleteRoomBlock(id int) error
+	FindRoomBlockReport(hotelID int, fromDate string, toDate string) (res *models.RoomBlockReportResponse, err error)
+	CheckInReservation(id int, req *models.CheckInRequest) (res *models.FrontDeskResponse, err error)
+	CheckOutReservation(id int) (res *models.FrontDeskResponse, err error)
+	ChangeOrderStatus(orderID int, status string) (res *models.OrderHistoryResponse, err error)
+	FindOrderHistory(orderID int) (res *models.OrderHistoryResponse, err error)
+	FindAllHotels() (hotels []*models.Hotel, err error)
+	CreateHotel(req *models.HotelRequest) (hotel *models.Hotel, err error)
+	UpdateHotel(id int, req *models.HotelRequest) (hotel *models.Hotel, err error)
+	DeactivateHotel(id int) (hotel *models.Hotel, err error)
+	FindRoomTypes() (roomTypes []*models.RoomType, err error)
+	CreateRoomType(req *models.RoomTypeRequest) (roomType *models.RoomType, err error)
+	UpdateRoomType(id int, req *models.RoomTypeRequest) (roomType *models.RoomType, err error)
+	DeactivateRoomType(id int) (roomType *models.RoomType, err error)
+	FindAllRooms(hotelID int) (res []*models.RoomResponse, err error)
+	CreateRoom(req *models.RoomRequest) (res *models.RoomResponse, err error)
+	UpdateRoom(id int, req *models.RoomRequest) (res *models.RoomResponse, err error)
+	DeactivateRoom(id int) (res *models.RoomResponse, err error)
+	FindPriceCalendar(hotelID int, roomTypeID int, fromDate string, toDate string) (res *models.PriceCalendarResponse, err error)
+	SetPriceRange(hotelID int, roomTypeID int, req *models.PriceRangeRequest) (res *models.PriceCalendarResponse, err error)
+	ImportPrices(hotelID int, roomTypeID int, file io.Reader) (res *models.PriceCalendarResponse, err error)
+	ExportPrices(hotelID int, roomTypeID int, fromDate string, toDate string, file io.Writer) error
+}
+
+//Clock tells the current time, it can be replaced to make time based promo rules deterministic
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
 }
 
 type hotelMgmtService struct {
 	repository repositories.HotelMgmtRepo
+	clock      Clock
 }
 
 func NewHotelMgmtService(repository repositories.HotelMgmtRepo) HotelMgmtService {
+	return NewHotelMgmtServiceWithClock(repository, systemClock{})
+}
+
+func NewHotelMgmtServiceWithClock(repository repositories.HotelMgmtRepo, clock Clock) HotelMgmtService {
 	return &hotelMgmtService{
 		repository: repository,
+		clock:      clock,
 	}
 }
 
+//function to list hotels which are not deactivated
+func (service *hotelMgmtService) FindHotels() (hotels []*models.Hotel, err error) {
+	all, err := service.repository.FindHotels()
+	if err != nil {
+		return nil, err
+	}
+	hotels = make([]*models.Hotel, 0, len(all))
+	for _, hotel := range all {
+		if hotel.DeactivatedAt == nil {
+			hotels = append(hotels, hotel)
+		}
+	}
+	return hotels, nil
+}
+
 //function to find available rooms
-func (service *hotelMgmtService) FindAvailableRooms(checkinDate string, checkoutDate string, roomQty int, roomTypeID int) (availableRooms *models.HotelAvailableRoomsResponse, err error) {
+func (service *hotelMgmtService) FindAvailableRooms(hotelID int, checkinDate string, checkoutDate string, roomQty int, roomTypeID int) (availableRooms *models.HotelAvailableRoomsResponse, err error) {
 	var wg sync.WaitGroup
-	totalPrice := 0
+	if err = service.validateStay(hotelID, checkinDate, checkoutDate, roomQty, false); err != nil {
+		return nil, err
+	}
 
-	//specify fields for fetching it to the repo
-	fields := "date >= ? AND date < ?"
-	ids, err := service.repository.FindRoomIDFromStayRoomByFields(fields, checkinDate, checkoutDate)
+	//fetching booked rooms, available rooms and nightly prices from the repo
+	ids, err := service.repository.FindBookedRoomIDs(hotelID, checkinDate, checkoutDate)
 	if err != nil {
-		ids = append(ids, 0)
+		return nil, err
 	}
-
-	//specify fields for fetching it to the repo
-	fields = "hotel_id = ? AND room_type_id = ?"
-	rooms, err := service.repository.FindAvailableRoomsByFields(ids, fields, 1, roomTypeID)
+	blockedIDs, err := service.blockedRoomIDs(hotelID, checkinDate, checkoutDate)
 	if err != nil {
 		return nil, err
 	}
-
-	//specify fields for fetching it to the repo
-	fields = "hotel_id = ? AND room_type_id = ? AND date >= ? AND date < ?"
-	prices, err := service.repository.FindPricesByFields(fields, 1, roomTypeID, checkinDate, checkoutDate)
+	ids = append(ids, blockedIDs...)
+	rooms, err := service.repository.FindRooms(hotelID, roomTypeID, ids)
 	if err != nil {
 		return nil, err
 	}
+	rooms = sellableRooms(rooms, checkinDate, checkoutDate)
 
 	if len(rooms) < roomQty {
-		return nil, errors.New("Total available rooms exceeded your requirement.")
+		return nil, fmt.Errorf("%w: %d of %d rooms are free", ErrNoAvailability, len(rooms), roomQty)
+	}
+	policy, err := service.findCancellationPolicy(hotelID, roomTypeID)
+	if err != nil {
+		return nil, err
 	}
 
-	//sum the total values from price list and change date format
-	wg.Add(len(prices))
-	for _, price := range prices {
-		go func(price *models.Price) {
-			defer wg.Done()
-			totalPrice = totalPrice + price.Price
-			price.Date = price.Date[0:10]
-		}(price)
+	//every night must have a price, a missing night would lower the total price
+	prices, totalPrice, err := service.nightlyPrices(hotelID, roomTypeID, checkinDate, checkoutDate)
+	if err != nil {
+		return nil, err
 	}
-	wg.Wait()
 
 	//assign price list data to rooms data
 	wg.Add(len(rooms))
@@ -79,12 +148,14 @@ func (service *hotelMgmtService) FindAvailableRooms(checkinDate string, checkout
 
 	//assign response model with processed data
 	availableRooms = &models.HotelAvailableRoomsResponse{
-		RoomQty:        roomQty,
-		RoomTypeID:     roomTypeID,
-		CheckinDate:    checkinDate,
-		CheckoutDate:   checkoutDate,
-		TotalPrice:     totalPrice,
-		AvailableRooms: rooms,
+		HotelID:            hotelID,
+		RoomQty:            roomQty,
+		RoomTypeID:         roomTypeID,
+		CheckinDate:        checkinDate,
+		CheckoutDate:       checkoutDate,
+		TotalPrice:         totalPrice,
+		CancellationPolicy: policy,
+		AvailableRooms:     rooms,
 	}
 
 	return availableRooms, nil
@@ -92,25 +163,178 @@ func (service *hotelMgmtService) FindAvailableRooms(checkinDate string, checkout
 
 //function to find room promotion price
 func (service *hotelMgmtService) FindPromoRooms(req *models.PromoRoomsRequest) (res *models.PromoRoomsResponse, err error) {
-	//initialize promo rules and promo prices
-	var wg sync.WaitGroup
+	//fetching required promos
+	promos, err := service.findPromos(req.PromoID, req.PromoCode, req.PromoIDs, req.PromoCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	//re-derive availability and nightly prices instead of trusting the request
+	availableRooms, err := service.FindAvailableRooms(req.HotelID, req.CheckinDate, req.CheckoutDate, req.RoomQty, req.RoomTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookingTime, err := service.hotelTime(req.HotelID)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.applyPromos(promos, availableRooms, bookingTime)
+}
+
+//function to find every promo that can be used for available rooms, ranked by discount
+func (service *hotelMgmtService) FindBestPromoRooms(hotelID int, checkinDate string, checkoutDate string, roomQty int, roomTypeID int) (res *models.BestPromoRoomsResponse, err error) {
+	availableRooms, err := service.FindAvailableRooms(hotelID, checkinDate, checkoutDate, roomQty, roomTypeID)
+	if err != nil {
+		return nil, err
+	}
+	promos, err := service.repository.FindPromos()
+	if err != nil {
+		return nil, err
+	}
+	bookingTime, err := service.hotelTime(hotelID)
+	if err != nil {
+		return nil, err
+	}
+
+	//evaluate each promo against the same availability, ineligible promos are left out
+	promoRooms := make([]*models.PromoRoomsResponse, 0, len(promos))
+	for _, promo := range promos {
+		promoRoom, err := service.applyPromos([]*models.Promo{promo}, availableRooms, bookingTime)
+		if errors.Is(err, ErrPromoIneligible) || errors.Is(err, ErrPromoFullyRedeemed) || errors.Is(err, ErrPromoCustomerLimit) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if promoRoom.PromoPrice <= 0 {
+			continue
+		}
+		promoRooms = append(promoRooms, promoRoom)
+	}
+	sort.SliceStable(promoRooms, func(i, j int) bool {
+		if promoRooms[i].PromoPrice != promoRooms[j].PromoPrice {
+			return promoRooms[i].PromoPrice > promoRooms[j].PromoPrice
+		}
+		return promoRooms[i].TotalPrice < promoRooms[j].TotalPrice
+	})
+
+	//assign response model with processed data
+	res = &models.BestPromoRoomsResponse{
+		HotelID:      hotelID,
+		RoomQty:      roomQty,
+		RoomTypeID:   roomTypeID,
+		CheckinDate:  checkinDate,
+		CheckoutDate: checkoutDate,
+		TotalPrice:   availableRooms.TotalPrice,
+		BestPrice:    availableRooms.TotalPrice,
+		Promos:       promoRooms,
+	}
+	for _, promoRoom := range promoRooms {
+		if promoRoom.TotalPrice < res.BestPrice {
+			res.BestPrice = promoRoom.TotalPrice
+			res.BestPromoID = promoRoom.PromoID
+		}
+	}
+	return res, nil
+}
+
+//function to apply one or more stacked promos to available rooms
+func (service *hotelMgmtService) applyPromos(promos []*models.Promo, availableRooms *models.HotelAvailableRoomsResponse, bookingTime time.Time) (res *models.PromoRoomsResponse, err error) {
+	if len(availableRooms.AvailableRooms) == 0 {
+		return nil, ErrNoAvailability
+	}
+	if err = validatePromoStacking(promos); err != nil {
+		return nil, err
+	}
+
+	//apply promos by priority, percentage promos before fixed promos
+	promos = sortPromos(promos)
+
+	//check rules of every promo and which nights it can discount
+	allowedNights := make([][]bool, len(promos))
+	for i, promo := range promos {
+		allowedNights[i], err = service.checkPromoRules(promo, availableRooms, bookingTime)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	//work on copies so the same availability can be priced with other promos
+	roomQty := availableRooms.RoomQty
 	promoPrice, totalPrice := 0, 0
+	contributions := make([]*models.PromoContribution, 0, len(promos))
+	for _, promo := range promos {
+		contributions = append(contributions, &models.PromoContribution{
+			PromoID:   promo.ID,
+			PromoCode: promoCode(promo),
+		})
+	}
+	roomPrices := make([]*models.Price, 0, len(availableRooms.AvailableRooms[0].Price))
+	for night, nightPrice := range availableRooms.AvailableRooms[0].Price {
+		price := *nightPrice
+		//a later promo can't take the night below the minimum night price of a promo applied before it
+		floor := 0
+		for i, promo := range promos {
+			if !allowedNights[i][night] {
+				continue
+			}
+			if promo.MinimumNightPrice > floor {
+				floor = promo.MinimumNightPrice
+			}
+			discount := promoDiscount(promo, price.Price, floor)
+			contributions[i].PromoPrice = contributions[i].PromoPrice + discount*roomQty
+			promoPrice = promoPrice + discount*roomQty
+			price.Price = price.Price - discount
+		}
+		roomPrices = append(roomPrices, &price)
+		totalPrice = totalPrice + price.Price*roomQty
+	}
+
+	rooms := make([]*models.Room, 0, len(availableRooms.AvailableRooms))
+	for _, availableRoom := range availableRooms.AvailableRooms {
+		room := *availableRoom
+		room.Price = roomPrices
+		rooms = append(rooms, &room)
+	}
+
+	//assign response model with processed data
+	res = &models.PromoRoomsResponse{
+		PromoID:        promos[0].ID,
+		PromoCode:      promoCode(promos[0]),
+		HotelID:        availableRooms.HotelID,
+		RoomQty:        roomQty,
+		RoomTypeID:     availableRooms.RoomTypeID,
+		CheckinDate:    availableRooms.CheckinDate,
+		CheckoutDate:   availableRooms.CheckoutDate,
+		PromoPrice:     promoPrice,
+		TotalPrice:     totalPrice,
+		Promos:         contributions,
+		AvailableRooms: rooms,
+	}
+	return res, nil
+}
+
+//function to check promo rules at booking time in hotel timezone, returns which nights of the stay the promo can discount
+func (service *hotelMgmtService) checkPromoRules(promo *models.Promo, availableRooms *models.HotelAvailableRoomsResponse, bookingTime time.Time) (allowedNights []bool, err error) {
+	//initialize promo rules
 	allowedForAnyStayDay := false
 	allowedForBookingDayPromo := false
 	allowedForBookingHourPromo := false
 
-	//fetching required promo and promo rules
-	promo, err := service.repository.FindPromoByID(req.PromoID)
-	if err != nil {
-		return nil, err
-	}
+	//fetching promo rules
 	promoStay, err := service.repository.FindStayPromoByID(promo.StayDayPromoID)
-	if err != nil {
+	if errors.Is(err, ErrNotFound) {
 		allowedForAnyStayDay = true
+	} else if err != nil {
+		return nil, err
 	}
 	promoBook, err := service.repository.FindBookingPromoByID(promo.BookingDayPromoID)
-	if err != nil {
+	if errors.Is(err, ErrNotFound) {
 		allowedForBookingDayPromo = true
+	} else if err != nil {
+		return nil, err
 	}
 
 	//validate promo rules data from database
@@ -118,34 +342,22 @@ func (service *hotelMgmtService) FindPromoRooms(req *models.PromoRoomsRequest) (
 		promo.MinimumNights < 0 || promo.MinimumRooms < 0 ||
 		(promo.BookingHourFirst == promo.BookingHourLast && promo.BookingHourFirst > 0) ||
 		(promo.IsPercentage && promo.Percentage < 0) ||
-		(!promo.IsPercentage && promo.Currency < 0) ||
-		len(req.AvailableRooms) == 0 {
-		return nil, errors.New("sorry, this promo currently unavailable")
+		(!promo.IsPercentage && promo.Currency < 0) {
+		ineligible := &PromoIneligibleError{PromoID: promo.ID}
+		ineligible.Add(PromoRuleUnavailable, "promo is currently unavailable", "")
+		return nil, ineligible
+	}
+	if promo.MaxRedemptions > 0 && promo.RedemptionCount >= promo.MaxRedemptions {
+		return nil, ErrPromoFullyRedeemed
 	}
 
 	//validate booking day promo rules data
 	if !allowedForBookingDayPromo {
-		bookingWeekday := time.Now().Local().Weekday()
-		switch int(bookingWeekday) {
-		case 0:
-			allowedForBookingDayPromo = promoBook.IsSunPromo
-		case 1:
-			allowedForBookingDayPromo = promoBook.IsMonPromo
-		case 2:
-			allowedForBookingDayPromo = promoBook.IsTuePromo
-		case 3:
-			allowedForBookingDayPromo = promoBook.IsWedPromo
-		case 4:
-			allowedForBookingDayPromo = promoBook.IsThuPromo
-		case 5:
-			allowedForBookingDayPromo = promoBook.IsFriPromo
-		case 6:
-			allowedForBookingDayPromo = promoBook.IsSatPromo
-		}
+		allowedForBookingDayPromo = bookingWeekdays(promoBook)[bookingTime.Weekday()]
 	}
 
 	//validate booking hour promo rules data
-	bookingHour := time.Now().Local().Hour()
+	bookingHour := bookingTime.Hour()
 	if promo.BookingHourFirst == 0 {
 		allowedForBookingHourPromo = true
 	} else if promo.BookingHourFirst > promo.BookingHourLast {
@@ -154,102 +366,252 @@ func (service *hotelMgmtService) FindPromoRooms(req *models.PromoRoomsRequest) (
 		allowedForBookingHourPromo = bookingHour >= promo.BookingHourFirst && bookingHour < promo.BookingHourLast
 	}
 
-	//get promo prices with specific rules
-	totalNights := len(req.AvailableRooms[0].Price)
-	if totalNights >= promo.MinimumNights && req.RoomQty >= promo.MinimumRooms &&
-		allowedForBookingDayPromo && allowedForBookingHourPromo {
-
-		roomPrices := req.AvailableRooms[0].Price
-		//get promo prices if no stay day rules
-		if allowedForAnyStayDay {
-			wg.Add(len(roomPrices))
-			for _, price := range roomPrices {
-				go func(price *models.Price) {
-					defer wg.Done()
-					if promo.IsPercentage {
-						temp := promo.Percentage * price.Price / 100
-						promoPrice = promoPrice + temp
-						price.Price = price.Price - temp
-						totalPrice = totalPrice + price.Price
-					} else {
-						promoPrice = promoPrice + promo.Currency
-						price.Price = price.Price - promo.Currency
-						totalPrice = totalPrice + price.Price
-					}
-				}(price)
-			}
-			wg.Wait()
-		} else {
-			//get promo prices if there are stay day rules
-			wg.Add(len(roomPrices))
-			for _, price := range roomPrices {
-				go func(price *models.Price) {
-					defer wg.Done()
-					allowedForPromo := false
-					day, err := time.Parse("2006-01-02", price.Date)
-					if err != nil {
-						totalPrice = totalPrice + price.Price
-						return
-					}
-					weekDay := day.Weekday()
-					switch int(weekDay) {
-					case 0:
-						allowedForPromo = promoStay.IsSunPromo
-					case 1:
-						allowedForPromo = promoStay.IsMonPromo
-					case 2:
-						allowedForPromo = promoStay.IsTuePromo
-					case 3:
-						allowedForPromo = promoStay.IsWedPromo
-					case 4:
-						allowedForPromo = promoStay.IsThuPromo
-					case 5:
-						allowedForPromo = promoStay.IsFriPromo
-					case 6:
-						allowedForPromo = promoStay.IsSatPromo
-					}
-					if allowedForPromo {
-						if promo.IsPercentage {
-							temp := promo.Percentage * price.Price / 100
-							promoPrice = promoPrice + temp
-							price.Price = price.Price - temp
-							totalPrice = totalPrice + price.Price
-						} else {
-							promoPrice = promoPrice + promo.Currency
-							price.Price = price.Price - promo.Currency
-							totalPrice = totalPrice + price.Price
-						}
-					} else {
-						totalPrice = totalPrice + price.Price
-					}
-				}(price)
-			}
-			wg.Wait()
-		}
-
-		//assign back price list data after promo pricesz
-		wg.Add(len(req.AvailableRooms))
-		for _, room := range req.AvailableRooms {
-			go func(room *models.Room) {
-				wg.Done()
-				room.Price = roomPrices
-			}(room)
-		}
-		wg.Wait()
-
-		//assign response model with processed data
-		res = &models.PromoRoomsResponse{
-			PromoID:        req.PromoID,
-			RoomQty:        req.RoomQty,
-			RoomTypeID:     req.RoomTypeID,
-			CheckinDate:    req.CheckinDate,
-			CheckoutDate:   req.CheckoutDate,
-			PromoPrice:     promoPrice,
-			TotalPrice:     totalPrice,
-			AvailableRooms: req.AvailableRooms,
+	//validate booking window promo rules data
+	bookingDate := bookingTime.Format(dateForm)
+	allowedForBookingWindow := withinDateWindow(bookingDate, promo.BookingValidFrom, promo.BookingValidUntil)
+
+	dates, err := stayDates(availableRooms.CheckinDate, availableRooms.CheckoutDate)
+	if err != nil {
+		return nil, err
+	}
+
+	//check stay day and stay window promo rules data for each night
+	totalNights := len(dates)
+	allowedNights = make([]bool, 0, totalNights)
+	anyAllowedNight := false
+	for _, date := range dates {
+		allowedForPromo := allowedForAnyStayDay
+		if !allowedForAnyStayDay {
+			day, _ := time.Parse(dateForm, date)
+			allowedForPromo = stayWeekdays(promoStay)[day.Weekday()]
 		}
-	} else {
-		return nil, errors.New("sorry, this promo can't be used for your request")
+		allowedForPromo = allowedForPromo && withinDateWindow(date, promo.StayValidFrom, promo.StayValidUntil)
+		allowedNights = append(allowedNights, allowedForPromo)
+		anyAllowedNight = anyAllowedNight || allowedForPromo
 	}
-	return res, nil
+
+	//every broken rule is reported with a hint to qualify
+	ineligible := &PromoIneligibleError{PromoID: promo.ID}
+	if totalNights < promo.MinimumNights {
+		missing := promo.MinimumNights - totalNights
+		ineligible.AddCount(PromoRuleMinimumNights, fmt.Sprintf("stay is %d nights, promo needs at least %d", totalNights, promo.MinimumNights),
+			totalNights, promo.MinimumNights, fmt.Sprintf("book %d more %s to qualify", missing, plural(missing, "night", "nights")))
+	}
+	if availableRooms.RoomQty < promo.MinimumRooms {
+		missing := promo.MinimumRooms - availableRooms.RoomQty
+		ineligible.AddCount(PromoRuleMinimumRooms, fmt.Sprintf("booking is %d rooms, promo needs at least %d", availableRooms.RoomQty, promo.MinimumRooms),
+			availableRooms.RoomQty, promo.MinimumRooms, fmt.Sprintf("book %d more %s to qualify", missing, plural(missing, "room", "rooms")))
+	}
+	if !allowedForBookingDayPromo {
+		ineligible.Add(PromoRuleBookingDay, fmt.Sprintf("promo can't be booked on %s", bookingTime.Weekday()),
+			fmt.Sprintf("book on %s to qualify", weekdayList(bookingWeekdays(promoBook))))
+	}
+	if !allowedForBookingHourPromo {
+		ineligible.Add(PromoRuleBookingHours, fmt.Sprintf("promo can't be booked at %02d:00", bookingHour),
+			fmt.Sprintf("book from %02d:00 until %02d:00 hotel time to qualify", promo.BookingHourFirst, promo.BookingHourLast))
+	}
+	if !allowedForBookingWindow {
+		hint := ""
+		if promo.BookingValidFrom != nil && bookingDate < promo.BookingValidFrom.Format(dateForm) {
+			hint = fmt.Sprintf("book from %s to qualify", promo.BookingValidFrom.Format(dateForm))
+		}
+		ineligible.Add(PromoRuleBookingWindow, fmt.Sprintf("promo can't be booked on %s", bookingDate), hint)
+	}
+	if !anyAllowedNight {
+		hints := make([]string, 0, 2)
+		if !allowedForAnyStayDay {
+			hints = append(hints, fmt.Sprintf("stay on a %s night", weekdayList(stayWeekdays(promoStay))))
+		}
+		if promo.StayValidFrom != nil || promo.StayValidUntil != nil {
+			hints = append(hints, "stay "+dateWindow(promo.StayValidFrom, promo.StayValidUntil))
+		}
+		ineligible.Add(PromoRuleStayDays, "promo discounts none of the nights of the stay", strings.Join(hints, " and ")+" to qualify")
+	}
+	if err = ineligible.Err(); err != nil {
+		return nil, err
+	}
+	return allowedNights, nil
+}
+
+//current time in the hotel timezone, hotels without timezone use UTC
+func (service *hotelMgmtService) hotelTime(hotelID int) (time.Time, error) {
+	hotel, err := service.repository.FindHotelByID(hotelID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	location, err := time.LoadLocation(hotel.Timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid timezone %q of hotel %d", ErrInternal, hotel.Timezone, hotel.ID)
+	}
+	return service.clock.Now().In(location), nil
+}
+
+//fetching every requested promo by code or id, skipping duplicates
+func (service *hotelMgmtService) findPromos(promoID int, promoCode string, promoIDs []int, promoCodes []string) ([]*models.Promo, error) {
+	if promoID != 0 {
+		promoIDs = append([]int{promoID}, promoIDs...)
+	}
+	if promoCode != "" {
+		promoCodes = append([]string{promoCode}, promoCodes...)
+	}
+	if len(promoIDs) == 0 && len(promoCodes) == 0 {
+		invalid := &ValidationError{}
+		invalid.Add("promo_id", RuleRequired, "promo_id or promo_code is required")
+		return nil, invalid
+	}
+
+	var promos []*models.Promo
+	found := make(map[int]bool)
+	for _, code := range promoCodes {
+		promo, err := service.repository.FindPromoByCode(code)
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("%w: promo code %q doesn't exist", ErrPromoNotFound, code)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !found[promo.ID] {
+			promos = append(promos, promo)
+			found[promo.ID] = true
+		}
+	}
+	for _, id := range promoIDs {
+		if found[id] {
+			continue
+		}
+		promo, err := service.repository.FindPromoByID(id)
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("%w: promo %d doesn't exist", ErrPromoNotFound, id)
+		}
+		if err != nil {
+			return nil, err
+		}
+		promos = append(promos, promo)
+		found[promo.ID] = true
+	}
+	return promos, nil
+}
+
+//check promos can be combined, exclusive promos stand alone and only one promo of a stacking group can be used
+func validatePromoStacking(promos []*models.Promo) error {
+	if len(promos) < 2 {
+		return nil
+	}
+	groups := make(map[int]*models.Promo)
+	for _, promo := range promos {
+		if promo.IsExclusive {
+			ineligible := &PromoIneligibleError{PromoID: promo.ID}
+			ineligible.Add(PromoRuleStacking, "promo can't be combined with other promos", "use this promo on its own")
+			return ineligible
+		}
+		if promo.StackingGroup == 0 {
+			continue
+		}
+		if other, ok := groups[promo.StackingGroup]; ok {
+			ineligible := &PromoIneligibleError{PromoID: promo.ID}
+			ineligible.Add(PromoRuleStacking, fmt.Sprintf("promo can't be combined with promo %d", other.ID), fmt.Sprintf("use either promo %d or promo %d", other.ID, promo.ID))
+			return ineligible
+		}
+		groups[promo.StackingGroup] = promo
+	}
+	return nil
+}
+
+//order promos by priority, then percentage before fixed, then id
+func sortPromos(promos []*models.Promo) []*models.Promo {
+	sorted := append([]*models.Promo(nil), promos...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority < sorted[j].Priority
+		}
+		if sorted[i].IsPercentage != sorted[j].IsPercentage {
+			return sorted[i].IsPercentage
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
+
+//discount of promo for a night price, never taking the price below zero or the floor night price
+func promoDiscount(promo *models.Promo, price int, floor int) int {
+	discount := promo.Currency
+	if promo.IsPercentage {
+		discount = promo.Percentage * price / 100
+	}
+	if floor < 0 {
+		floor = 0
+	}
+	if price-discount < floor {
+		discount = price - floor
+	}
+	if discount < 0 {
+		discount = 0
+	}
+	return discount
+}
+
+//check date is inside promo validity window, an empty bound means unlimited
+func withinDateWindow(date string, validFrom *time.Time, validUntil *time.Time) bool {
+	if validFrom != nil && date < validFrom.Format(dateForm) {
+		return false
+	}
+	if validUntil != nil && date > validUntil.Format(dateForm) {
+		return false
+	}
+	return true
+}
+
+//days of week allowed by booking day promo rules, indexed by weekday
+func bookingWeekdays(days *models.BookingDayPromo) [7]bool {
+	return [7]bool{days.IsSunPromo, days.IsMonPromo, days.IsTuePromo, days.IsWedPromo, days.IsThuPromo, days.IsFriPromo, days.IsSatPromo}
+}
+
+//days of week allowed by stay day promo rules, indexed by weekday
+func stayWeekdays(days *models.StayDayPromo) [7]bool {
+	return [7]bool{days.IsSunPromo, days.IsMonPromo, days.IsTuePromo, days.IsWedPromo, days.IsThuPromo, days.IsFriPromo, days.IsSatPromo}
+}
+
+//names of the allowed days of week like "Friday or Saturday"
+func weekdayList(days [7]bool) string {
+	var names []string
+	for day, allowed := range days {
+		if allowed {
+			names = append(names, time.Weekday(day).String())
+		}
+	}
+	if len(names) == 0 {
+		return "no day"
+	}
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
+
+//describe a date window which may be open on either side
+func dateWindow(validFrom *time.Time, validUntil *time.Time) string {
+	switch {
+	case validFrom != nil && validUntil != nil:
+		return fmt.Sprintf("from %s until %s", validFrom.Format(dateForm), validUntil.Format(dateForm))
+	case validFrom != nil:
+		return "from " + validFrom.Format(dateForm)
+	default:
+		return "until " + validUntil.Format(dateForm)
+	}
+}
+
+//pick the singular or plural word for a count
+func plural(count int, singular string, plural string) string {
+	if count == 1 {
+		return singular
+	}
+	return plural
+}
+
+//code of the promo, promos applied without code have an empty code
+func promoCode(promo *models.Promo) string {
+	if promo.Code == nil {
+		return ""
+	}
+	return *promo.Code
 }
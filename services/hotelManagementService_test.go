@@ -0,0 +1,495 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+//stay from friday 2022-12-09 to monday 2022-12-12, booked on monday 2022-12-05
+const (
+	testCheckin    = "2022-12-09"
+	testCheckout   = "2022-12-12"
+	testNights     = 3
+	testNightPrice = 100000
+)
+
+func at(value string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func date(value string) *time.Time {
+	t, err := time.Parse(dateForm, value)
+	if err != nil {
+		panic(err)
+	}
+	return &t
+}
+
+func TestFindPromoRoomsRules(t *testing.T) {
+	monday := at("2022-12-05 10:00")
+	weekend := &models.StayDayPromo{ID: 1, IsSatPromo: true, IsSunPromo: true}
+	weekdays := &models.StayDayPromo{ID: 1, IsMonPromo: true, IsTuePromo: true, IsWedPromo: true}
+	onMonday := &models.BookingDayPromo{ID: 1, IsMonPromo: true}
+	onTuesday := &models.BookingDayPromo{ID: 1, IsTuePromo: true}
+
+	tests := []struct {
+		name           string
+		promo          models.Promo
+		stayDay        *models.StayDayPromo
+		bookingDay     *models.BookingDayPromo
+		now            time.Time
+		timezone       string
+		roomQty        int
+		wantErr        error
+		wantPromoPrice int
+		wantTotalPrice int
+	}{
+		{
+			name:           "percentage promo discounts every night",
+			promo:          models.Promo{IsPercentage: true, Percentage: 10},
+			wantPromoPrice: 30000,
+			wantTotalPrice: 270000,
+		},
+		{
+			name:           "fixed promo is multiplied by room quantity",
+			promo:          models.Promo{Currency: 20000},
+			roomQty:        2,
+			wantPromoPrice: 120000,
+			wantTotalPrice: 480000,
+		},
+		{
+			name:           "minimum nights met",
+			promo:          models.Promo{MinimumNights: 3, Currency: 10000},
+			wantPromoPrice: 30000,
+			wantTotalPrice: 270000,
+		},
+		{
+			name:    "minimum nights not met",
+			promo:   models.Promo{MinimumNights: 4, Currency: 10000},
+			wantErr: ErrPromoIneligible,
+		},
+		{
+			name:           "minimum rooms met",
+			promo:          models.Promo{MinimumRooms: 2, Currency: 10000},
+			roomQty:        2,
+			wantPromoPrice: 60000,
+			wantTotalPrice: 540000,
+		},
+		{
+			name:    "minimum rooms not met",
+			promo:   models.Promo{MinimumRooms: 2, Currency: 10000},
+			wantErr: ErrPromoIneligible,
+		},
+		{
+			name:           "booking day allowed",
+			promo:          models.Promo{BookingDayPromoID: 1, Currency: 10000},
+			bookingDay:     onMonday,
+			wantPromoPrice: 30000,
+			wantTotalPrice: 270000,
+		},
+		{
+			name:       "booking day not allowed",
+			promo:      models.Promo{BookingDayPromoID: 1, Currency: 10000},
+			bookingDay: onTuesday,
+			wantErr:    ErrPromoIneligible,
+		},
+		{
+			name:           "missing booking day rules allow any day",
+			promo:          models.Promo{BookingDayPromoID: 9, Currency: 10000},
+			wantPromoPrice: 30000,
+			wantTotalPrice: 270000,
+		},
+		{
+			name:           "booking hour inside window",
+			promo:          models.Promo{BookingHourFirst: 9, BookingHourLast: 17, Currency: 10000},
+			wantPromoPrice: 30000,
+			wantTotalPrice: 270000,
+		},
+		{
+			name:           "booking hour first is inclusive",
+			promo:          models.Promo{BookingHourFirst: 10, BookingHourLast: 12, Currency: 10000},
+			wantPromoPrice: 30000,
+			wantTotalPrice: 270000,
+		},
+		{
+			name:    "booking hour last is exclusive",
+			promo:   models.Promo{BookingHourFirst: 8, BookingHourLast: 10, Currency: 10000},
+			wantErr: ErrPromoIneligible,
+		},
+		{
+			name:           "overnight booking hours late evening",
+			promo:          models.Promo{BookingHourFirst: 22, BookingHourLast: 6, Currency: 10000},
+			now:            at("2022-12-05 23:00"),
+			wantPromoPrice: 30000,
+			wantTotalPrice: 270000,
+		},
+		{
+			name:           "overnight booking hours early morning",
+			promo:          models.Promo{BookingHourFirst: 22, BookingHourLast: 6, Currency: 10000},
+			now:            at("2022-12-05 03:00"),
+			wantPromoPrice: 30000,
+			wantTotalPrice: 270000,
+		},
+		{
+			name:    "overnight booking hours during the day",
+			promo:   models.Promo{BookingHourFirst: 22, BookingHourLast: 6, Currency: 10000},
+			wantErr: ErrPromoIneligible,
+		},
+		{
+			name:    "overnight booking hours last is exclusive",
+			promo:   models.Promo{BookingHourFirst: 22, BookingHourLast: 6, Currency: 10000},
+			now:     at("2022-12-05 06:00"),
+			wantErr: ErrPromoIneligible,
+		},
+		{
+			name:           "booking hour first zero allows any hour",
+			promo:          models.Promo{BookingHourFirst: 0, BookingHourLast: 5, Currency: 10000},
+			wantPromoPrice: 30000,
+			wantTotalPrice: 270000,
+		},
+		{
+			name:    "booking hours with same first and last are invalid",
+			promo:   models.Promo{BookingHourFirst: 10, BookingHourLast: 10, Currency: 10000},
+			wantErr: ErrPromoIneligible,
+		},
+		{
+			name:    "booking hour after 23 is invalid",
+			promo:   models.Promo{BookingHourFirst: 9, BookingHourLast: 24, Currency: 10000},
+			wantErr: ErrPromoIneligible,
+		},
+		{
+			name:    "negative percentage is invalid",
+			promo:   models.Promo{IsPercentage: true, Percentage: -10},
+			wantErr: ErrPromoIneligible,
+		},
+		{
+			name:    "negative currency is invalid",
+			promo:   models.Promo{Currency: -10000},
+			wantErr: ErrPromoIneligible,
+		},
+		{
+			name:    "negative minimum nights is invalid",
+			promo:   models.Promo{MinimumNights: -1, Currency: 10000},
+			wantErr: ErrPromoIneligible,
+		},
+		{
+			name:           "stay day rules discount weekend nights only",
+			promo:          models.Promo{StayDayPromoID: 1, IsPercentage: true, Percentage: 50},
+			stayDay:        weekend,
+			wantPromoPrice: 100000,
+			wantTotalPrice: 200000,
+		},
+		{
+			name:    "stay day rules without matching night",
+			promo:   models.Promo{StayDayPromoID: 1, IsPercentage: true, Percentage: 50},
+			stayDay: weekdays,
+			wantErr: ErrPromoIneligible,
+		},
+		{
+			name:           "stay window limits discounted nights",
+			promo:          models.Promo{StayValidUntil: date("2022-12-10"), IsPercentage: true, Percentage: 10},
+			wantPromoPrice: 20000,
+			wantTotalPrice: 280000,
+		},
+		{
+			name:    "booking window not started",
+			promo:   models.Promo{BookingValidFrom: date("2022-12-06"), Currency: 10000},
+			wantErr: ErrPromoIneligible,
+		},
+		{
+			name:           "booking window last day is inclusive",
+			promo:          models.Promo{BookingValidUntil: date("2022-12-05"), Currency: 10000},
+			wantPromoPrice: 30000,
+			wantTotalPrice: 270000,
+		},
+		{
+			name:    "fully redeemed promo",
+			promo:   models.Promo{MaxRedemptions: 5, RedemptionCount: 5, Currency: 10000},
+			wantErr: ErrPromoFullyRedeemed,
+		},
+		{
+			name:           "discount never takes a night below zero",
+			promo:          models.Promo{Currency: 150000},
+			wantPromoPrice: 300000,
+			wantTotalPrice: 0,
+		},
+		{
+			name:           "discount never takes a night below minimum night price",
+			promo:          models.Promo{Currency: 50000, MinimumNightPrice: 80000},
+			wantPromoPrice: 60000,
+			wantTotalPrice: 240000,
+		},
+		{
+			name:           "booking hour is evaluated in hotel timezone",
+			promo:          models.Promo{BookingHourFirst: 22, BookingHourLast: 6, Currency: 10000},
+			now:            at("2022-12-05 16:00"),
+			timezone:       "Asia/Jakarta",
+			wantPromoPrice: 30000,
+			wantTotalPrice: 270000,
+		},
+		{
+			name:           "booking day is evaluated in hotel timezone",
+			promo:          models.Promo{BookingDayPromoID: 1, Currency: 10000},
+			bookingDay:     onTuesday,
+			now:            at("2022-12-05 20:00"),
+			timezone:       "Asia/Jakarta",
+			wantPromoPrice: 30000,
+			wantTotalPrice: 270000,
+		},
+		{
+			name:       "booking day in server timezone is not used",
+			promo:      models.Promo{BookingDayPromoID: 1, Currency: 10000},
+			bookingDay: onTuesday,
+			now:        at("2022-12-05 20:00"),
+			wantErr:    ErrPromoIneligible,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepo(3, testCheckin, testNights, testNightPrice)
+			promo := tt.promo
+			promo.ID = 1
+			repo.promos = []*models.Promo{&promo}
+			if tt.stayDay != nil {
+				repo.stayPromos[tt.stayDay.ID] = tt.stayDay
+			}
+			if tt.bookingDay != nil {
+				repo.bookingPromos[tt.bookingDay.ID] = tt.bookingDay
+			}
+			if tt.timezone != "" {
+				repo.hotel.Timezone = tt.timezone
+			}
+			now := tt.now
+			if now.IsZero() {
+				now = monday
+			}
+			roomQty := tt.roomQty
+			if roomQty == 0 {
+				roomQty = 1
+			}
+
+			service := NewHotelMgmtServiceWithClock(repo, fixedClock(now))
+			res, err := service.FindPromoRooms(&models.PromoRoomsRequest{
+				PromoID:      1,
+				HotelID:      1,
+				RoomQty:      roomQty,
+				RoomTypeID:   1,
+				CheckinDate:  testCheckin,
+				CheckoutDate: testCheckout,
+			})
+			checkPromoResult(t, res, err, tt.wantErr, tt.wantPromoPrice, tt.wantTotalPrice)
+		})
+	}
+}
+
+func TestFindPromoRoomsStacking(t *testing.T) {
+	percentage := models.Promo{ID: 1, IsPercentage: true, Percentage: 10}
+	fixed := models.Promo{ID: 2, Currency: 10000}
+
+	tests := []struct {
+		name              string
+		promos            []models.Promo
+		wantErr           error
+		wantPromoPrice    int
+		wantTotalPrice    int
+		wantContributions []int
+	}{
+		{
+			name:              "percentage promo is applied before fixed promo",
+			promos:            []models.Promo{fixed, percentage},
+			wantPromoPrice:    60000,
+			wantTotalPrice:    240000,
+			wantContributions: []int{30000, 30000},
+		},
+		{
+			name: "priority overrides percentage before fixed",
+			promos: []models.Promo{
+				percentage,
+				{ID: 2, Currency: 10000, Priority: -1},
+			},
+			wantPromoPrice:    57000,
+			wantTotalPrice:    243000,
+			wantContributions: []int{30000, 27000},
+		},
+		{
+			name: "exclusive promo can't be combined",
+			promos: []models.Promo{
+				{ID: 1, IsPercentage: true, Percentage: 10, IsExclusive: true},
+				fixed,
+			},
+			wantErr: ErrPromoIneligible,
+		},
+		{
+			name: "promos of the same stacking group can't be combined",
+			promos: []models.Promo{
+				{ID: 1, IsPercentage: true, Percentage: 10, StackingGroup: 1},
+				{ID: 2, Currency: 10000, StackingGroup: 1},
+			},
+			wantErr: ErrPromoIneligible,
+		},
+		{
+			name: "promos of different stacking groups are combined",
+			promos: []models.Promo{
+				{ID: 1, IsPercentage: true, Percentage: 10, StackingGroup: 1},
+				{ID: 2, Currency: 10000, StackingGroup: 2},
+			},
+			wantPromoPrice:    60000,
+			wantTotalPrice:    240000,
+			wantContributions: []int{30000, 30000},
+		},
+		{
+			name: "stacked discounts stop at minimum night price",
+			promos: []models.Promo{
+				{ID: 1, IsPercentage: true, Percentage: 50},
+				{ID: 2, Currency: 30000, MinimumNightPrice: 40000},
+			},
+			wantPromoPrice:    180000,
+			wantTotalPrice:    120000,
+			wantContributions: []int{150000, 30000},
+		},
+		{
+			name: "later discount keeps minimum night price of earlier promo",
+			promos: []models.Promo{
+				{ID: 1, IsPercentage: true, Percentage: 30, MinimumNightPrice: 70000},
+				{ID: 2, Currency: 20000},
+			},
+			wantPromoPrice:    90000,
+			wantTotalPrice:    210000,
+			wantContributions: []int{90000, 0},
+		},
+		{
+			name: "one ineligible promo rejects the stack",
+			promos: []models.Promo{
+				percentage,
+				{ID: 2, Currency: 10000, MinimumNights: 7},
+			},
+			wantErr: ErrPromoIneligible,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepo(3, testCheckin, testNights, testNightPrice)
+			var ids []int
+			for i := range tt.promos {
+				repo.promos = append(repo.promos, &tt.promos[i])
+				ids = append(ids, tt.promos[i].ID)
+			}
+
+			service := NewHotelMgmtServiceWithClock(repo, fixedClock(at("2022-12-05 10:00")))
+			res, err := service.FindPromoRooms(&models.PromoRoomsRequest{
+				PromoIDs:     ids,
+				HotelID:      1,
+				RoomQty:      1,
+				RoomTypeID:   1,
+				CheckinDate:  testCheckin,
+				CheckoutDate: testCheckout,
+			})
+			checkPromoResult(t, res, err, tt.wantErr, tt.wantPromoPrice, tt.wantTotalPrice)
+			if err != nil {
+				return
+			}
+			if len(res.Promos) != len(tt.wantContributions) {
+				t.Fatalf("expected %d promo contributions, got %d", len(tt.wantContributions), len(res.Promos))
+			}
+			for i, want := range tt.wantContributions {
+				if res.Promos[i].PromoPrice != want {
+					t.Errorf("promo %d contribution: expected %d, got %d", res.Promos[i].PromoID, want, res.Promos[i].PromoPrice)
+				}
+			}
+		})
+	}
+}
+
+func TestFindBestPromoRooms(t *testing.T) {
+	repo := newFakeRepo(3, testCheckin, testNights, testNightPrice)
+	repo.promos = []*models.Promo{
+		{ID: 1, Currency: 10000},
+		{ID: 2, IsPercentage: true, Percentage: 25},
+		{ID: 3, Currency: 50000, MinimumNights: 5},
+	}
+
+	service := NewHotelMgmtServiceWithClock(repo, fixedClock(at("2022-12-05 10:00")))
+	res, err := service.FindBestPromoRooms(1, testCheckin, testCheckout, 1, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.Promos) != 2 || res.Promos[0].PromoID != 2 || res.Promos[1].PromoID != 1 {
+		t.Fatalf("expected promos 2 and 1 ranked by discount, got %+v", res.Promos)
+	}
+	if res.TotalPrice != 300000 || res.BestPrice != 225000 || res.BestPromoID != 2 {
+		t.Fatalf("unexpected best price: total %d, best %d with promo %d", res.TotalPrice, res.BestPrice, res.BestPromoID)
+	}
+
+	//a broken promo is an error, not a promo that doesn't apply
+	repo.promoErr = errors.New("connection refused")
+	if _, err = service.FindBestPromoRooms(1, testCheckin, testCheckout, 1, 1); err != repo.promoErr {
+		t.Fatalf("expected repository error to fail the search, got %v", err)
+	}
+}
+
+//errAny marks a case that must fail without caring about the error value
+var errAny = errors.New("any error")
+
+func checkPromoResult(t *testing.T, res *models.PromoRoomsResponse, err error, wantErr error, wantPromoPrice int, wantTotalPrice int) {
+	t.Helper()
+	if wantErr != nil {
+		if err == nil {
+			t.Fatalf("expected error, got promo price %d and total price %d", res.PromoPrice, res.TotalPrice)
+		}
+		if wantErr != errAny && !errors.Is(err, wantErr) {
+			t.Fatalf("expected error %v, got %v", wantErr, err)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.PromoPrice != wantPromoPrice || res.TotalPrice != wantTotalPrice {
+		t.Fatalf("expected promo price %d and total price %d, got %d and %d", wantPromoPrice, wantTotalPrice, res.PromoPrice, res.TotalPrice)
+	}
+}
+
+func TestPromoIneligibleListsEveryFailedRule(t *testing.T) {
+	repo := newFakeRepo(3, testCheckin, testNights, testNightPrice)
+	repo.promos = []*models.Promo{{ID: 1, StayDayPromoID: 1, BookingDayPromoID: 1, MinimumNights: 4, MinimumRooms: 3, BookingHourFirst: 22, BookingHourLast: 6, Currency: 10000}}
+	repo.stayPromos[1] = &models.StayDayPromo{ID: 1, IsMonPromo: true, IsTuePromo: true}
+	repo.bookingPromos[1] = &models.BookingDayPromo{ID: 1, IsSatPromo: true}
+	service := NewHotelMgmtServiceWithClock(repo, fixedClock(at("2022-12-05 10:00")))
+
+	_, err := service.FindPromoRooms(&models.PromoRoomsRequest{
+		PromoID:      1,
+		HotelID:      1,
+		RoomQty:      1,
+		RoomTypeID:   1,
+		CheckinDate:  testCheckin,
+		CheckoutDate: testCheckout,
+	})
+	var ineligible *PromoIneligibleError
+	if !errors.As(err, &ineligible) {
+		t.Fatalf("expected promo ineligible error, got %v", err)
+	}
+	want := []models.PromoRuleFailure{
+		{Rule: PromoRuleMinimumNights, Have: 3, Need: 4, Hint: "book 1 more night to qualify"},
+		{Rule: PromoRuleMinimumRooms, Have: 1, Need: 3, Hint: "book 2 more rooms to qualify"},
+		{Rule: PromoRuleBookingDay, Hint: "book on Saturday to qualify"},
+		{Rule: PromoRuleBookingHours, Hint: "book from 22:00 until 06:00 hotel time to qualify"},
+		{Rule: PromoRuleStayDays, Hint: "stay on a Monday or Tuesday night to qualify"},
+	}
+	if len(ineligible.FailedRules) != len(want) {
+		t.Fatalf("expected %d failed rules, got %d: %v", len(want), len(ineligible.FailedRules), err)
+	}
+	for i, failure := range ineligible.FailedRules {
+		if failure.Rule != want[i].Rule || failure.Have != want[i].Have || failure.Need != want[i].Need || failure.Hint != want[i].Hint {
+			t.Fatalf("failed rule %d: expected %+v, got %+v", i, want[i], failure)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+var (
+	//ErrInvalidRoomStatus is returned when the room can't move from its status to the requested one
+	ErrInvalidRoomStatus = errors.New("invalid room status")
+	//ErrRoomBooked is returned when a room with sold nights is taken out of order
+	ErrRoomBooked = errors.New("room is booked for these dates")
+)
+
+//last date used for out-of-order rooms without until date
+const openEndedDate = "9999-12-31"
+
+//statuses each room status can move to, out-of-order and out-of-service can be set again to change their dates
+var roomStatusTransitions = map[string][]string{
+	models.RoomStatusClean:        {models.RoomStatusDirty, models.RoomStatusInspected, models.RoomStatusOutOfOrder, models.RoomStatusOutOfService},
+	models.RoomStatusDirty:        {models.RoomStatusClean, models.RoomStatusOutOfOrder, models.RoomStatusOutOfService},
+	models.RoomStatusInspected:    {models.RoomStatusDirty, models.RoomStatusOutOfOrder, models.RoomStatusOutOfService},
+	models.RoomStatusOutOfOrder:   {models.RoomStatusDirty, models.RoomStatusClean, models.RoomStatusOutOfOrder, models.RoomStatusOutOfService},
+	models.RoomStatusOutOfService: {models.RoomStatusDirty, models.RoomStatusClean, models.RoomStatusOutOfOrder, models.RoomStatusOutOfService},
+}
+
+//function to update housekeeping status of a room
+func (service *hotelMgmtService) UpdateRoomStatus(roomID int, req *models.RoomStatusRequest) (room *models.Room, err error) {
+	room, err = service.repository.FindRoomByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkRoomStatusTransition(roomStatus(room), req.Status); err != nil {
+		return nil, err
+	}
+
+	room.RoomStatus = req.Status
+	room.StatusFromDate, room.StatusUntilDate = nil, nil
+	if req.Status == models.RoomStatusOutOfOrder || req.Status == models.RoomStatusOutOfService {
+		//out of order from today until further notice unless dates are given
+		now, err := service.hotelTime(room.HotelID)
+		if err != nil {
+			return nil, err
+		}
+		fromDate, untilDate := now.Format(dateForm), openEndedDate
+		if req.FromDate != "" {
+			fromDate = req.FromDate
+		}
+		if req.UntilDate != "" {
+			untilDate = req.UntilDate
+		}
+		from, until, err := dateRange("from_date", fromDate, "until_date", untilDate)
+		if err != nil {
+			return nil, err
+		}
+		room.StatusFromDate = &from
+		if untilDate != openEndedDate {
+			room.StatusUntilDate = &until
+		}
+
+		//sold nights can't be taken out of inventory
+		if req.Status == models.RoomStatusOutOfOrder {
+			nights, err := service.repository.FindStayRooms(room.HotelID, fromDate, untilDate)
+			if err != nil {
+				return nil, err
+			}
+			for _, night := range nights {
+				if night.RoomID == room.ID {
+					return nil, fmt.Errorf("%w: room %d is sold on %s", ErrRoomBooked, room.RoomNumber, night.Date[0:10])
+				}
+			}
+		}
+	}
+
+	if err = service.repository.UpdateRoomStatus(room); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+//function to list housekeeping tasks of every room of a hotel on a day, today in hotel timezone when date is empty
+func (service *hotelMgmtService) FindHousekeepingBoard(hotelID int, date string) (res *models.HousekeepingBoardResponse, err error) {
+	if date == "" {
+		now, err := service.hotelTime(hotelID)
+		if err != nil {
+			return nil, err
+		}
+		date = now.Format(dateForm)
+	}
+	day, err := time.Parse(dateForm, date)
+	if err != nil {
+		invalid := &ValidationError{}
+		invalid.Add("date", RuleFormat, fmt.Sprintf("date must look like %s", dateForm))
+		return nil, invalid
+	}
+	previousDate, nextDate := day.AddDate(0, 0, -1).Format(dateForm), day.AddDate(0, 0, 1).Format(dateForm)
+
+	rooms, err := service.repository.FindHotelRooms(hotelID)
+	if err != nil {
+		return nil, err
+	}
+	nights, err := service.repository.FindStayRooms(hotelID, previousDate, nextDate)
+	if err != nil {
+		return nil, err
+	}
+
+	//stay of each room on the night before and the night of the day
+	previousStays := make(map[int]int)
+	stays := make(map[int]int)
+	for _, night := range nights {
+		if night.Date[0:10] == previousDate {
+			previousStays[night.RoomID] = night.StayID
+		} else {
+			stays[night.RoomID] = night.StayID
+		}
+	}
+
+	res = &models.HousekeepingBoardResponse{
+		HotelID: hotelID,
+		Date:    date,
+		Tasks:   make([]*models.HousekeepingTask, 0, len(rooms)),
+	}
+	for _, room := range rooms {
+		if room.DeactivatedAt != nil {
+			continue
+		}
+		task := &models.HousekeepingTask{
+			RoomID:     room.ID,
+			RoomNumber: room.RoomNumber,
+			RoomTypeID: room.RoomTypeID,
+			RoomStatus: roomStatus(room),
+			Occupancy:  occupancy(previousStays[room.ID], stays[room.ID]),
+		}
+		task.Task = housekeepingTask(room, task.Occupancy, date, nextDate)
+		res.Tasks = append(res.Tasks, task)
+	}
+	return res, nil
+}
+
+//check room can move from one housekeeping status to another
+func checkRoomStatusTransition(from string, to string) error {
+	for _, next := range roomStatusTransitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	if _, ok := roomStatusTransitions[to]; !ok {
+		return fmt.Errorf("%w: unknown status %s", ErrInvalidRoomStatus, to)
+	}
+	return fmt.Errorf("%w: room can't move from %s to %s", ErrInvalidRoomStatus, from, to)
+}
+
+//housekeeping status of a room, rooms saved without status are clean
+func roomStatus(room *models.Room) string {
+	if room.RoomStatus == "" {
+		return models.RoomStatusClean
+	}
+	return room.RoomStatus
+}
+
+//check the out-of-order or out-of-service status of a room overlaps from date until to date
+func isRoomOutOfOrder(room *models.Room, status string, fromDate string, toDate string) bool {
+	if room.RoomStatus != status {
+		return false
+	}
+	if room.StatusFromDate != nil && room.StatusFromDate.Format(dateForm) >= toDate {
+		return false
+	}
+	if room.StatusUntilDate != nil && room.StatusUntilDate.Format(dateForm) <= fromDate {
+		return false
+	}
+	return true
+}
+
+//leave out rooms which are out of order on any night between checkin date and checkout date
+func sellableRooms(rooms []*models.Room, checkinDate string, checkoutDate string) []*models.Room {
+	sellable := rooms[:0]
+	for _, room := range rooms {
+		if !isRoomOutOfOrder(room, models.RoomStatusOutOfOrder, checkinDate, checkoutDate) {
+			sellable = append(sellable, room)
+		}
+	}
+	return sellable
+}
+
+//occupancy of a room from the stays of the night before and the night of the day
+func occupancy(previousStayID int, stayID int) string {
+	switch {
+	case previousStayID != 0 && stayID != 0 && previousStayID == stayID:
+		return models.OccupancyStayover
+	case previousStayID != 0 && stayID != 0:
+		return models.OccupancyTurnover
+	case previousStayID != 0:
+		return models.OccupancyDeparture
+	case stayID != 0:
+		return models.OccupancyArrival
+	}
+	return models.OccupancyVacant
+}
+
+//housekeeping task of a room on the day
+func housekeepingTask(room *models.Room, occupancy string, date string, nextDate string) string {
+	switch {
+	case isRoomOutOfOrder(room, models.RoomStatusOutOfOrder, date, nextDate),
+		isRoomOutOfOrder(room, models.RoomStatusOutOfService, date, nextDate):
+		return models.TaskMaintenance
+	case occupancy == models.OccupancyDeparture, occupancy == models.OccupancyTurnover, roomStatus(room) == models.RoomStatusDirty:
+		return models.TaskClean
+	case occupancy == models.OccupancyStayover:
+		return models.TaskService
+	case occupancy == models.OccupancyArrival && roomStatus(room) == models.RoomStatusClean:
+		return models.TaskInspect
+	}
+	return models.TaskNone
+}
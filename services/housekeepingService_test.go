@@ -0,0 +1,59 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+func TestFindAvailableRoomsSkipsOutOfOrderRooms(t *testing.T) {
+	repo := newFakeRepo(4, "2022-12-09", 3, 100000)
+	from, until := date("2022-12-10"), date("2022-12-11")
+	repo.rooms[0].RoomStatus = models.RoomStatusOutOfOrder
+	repo.rooms[0].StatusFromDate, repo.rooms[0].StatusUntilDate = from, until
+	repo.rooms[1].RoomStatus = models.RoomStatusOutOfService
+	repo.rooms[1].StatusFromDate = from
+	repo.rooms[2].RoomStatus = models.RoomStatusOutOfOrder
+	repo.rooms[2].StatusFromDate = date("2022-12-12")
+	service := NewHotelMgmtServiceWithClock(repo, fixedClock(at("2022-12-05 10:00")))
+
+	availableRooms, err := service.FindAvailableRooms(1, "2022-12-09", "2022-12-12", 1, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var ids []int
+	for _, room := range availableRooms.AvailableRooms {
+		ids = append(ids, room.ID)
+	}
+	if len(ids) != 3 || ids[0] != 2 || ids[1] != 3 || ids[2] != 4 {
+		t.Fatalf("expected rooms 2, 3 and 4, got %v", ids)
+	}
+}
+
+func TestHousekeepingTask(t *testing.T) {
+	outOfOrderFrom := time.Date(2022, 12, 10, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		room      *models.Room
+		occupancy string
+		want      string
+	}{
+		{"vacant clean room", &models.Room{RoomStatus: models.RoomStatusClean}, models.OccupancyVacant, models.TaskNone},
+		{"vacant dirty room", &models.Room{RoomStatus: models.RoomStatusDirty}, models.OccupancyVacant, models.TaskClean},
+		{"departure", &models.Room{RoomStatus: models.RoomStatusInspected}, models.OccupancyDeparture, models.TaskClean},
+		{"turnover", &models.Room{}, models.OccupancyTurnover, models.TaskClean},
+		{"stayover", &models.Room{RoomStatus: models.RoomStatusClean}, models.OccupancyStayover, models.TaskService},
+		{"arrival in clean room", &models.Room{}, models.OccupancyArrival, models.TaskInspect},
+		{"arrival in inspected room", &models.Room{RoomStatus: models.RoomStatusInspected}, models.OccupancyArrival, models.TaskNone},
+		{"out of order today", &models.Room{RoomStatus: models.RoomStatusOutOfOrder}, models.OccupancyVacant, models.TaskMaintenance},
+		{"out of order later", &models.Room{RoomStatus: models.RoomStatusOutOfOrder, StatusFromDate: &outOfOrderFrom}, models.OccupancyVacant, models.TaskNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := housekeepingTask(tt.room, tt.occupancy, "2022-12-09", "2022-12-10"); got != tt.want {
+				t.Fatalf("expected task %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+//function to search rooms of several room types for one stay, booked and blocked rooms are fetched once for every line
+func (service *hotelMgmtService) FindMultiRoomAvailability(req *models.MultiRoomSearchRequest) (res *models.MultiRoomSearchResponse, err error) {
+	roomQty, err := validateRoomLines(req.Lines)
+	if err != nil {
+		return nil, err
+	}
+	if err = service.validateStay(req.HotelID, req.CheckinDate, req.CheckoutDate, roomQty, false); err != nil {
+		return nil, err
+	}
+
+	//rooms of any line which can't be sold on some night of the stay
+	ids, err := service.repository.FindBookedRoomIDs(req.HotelID, req.CheckinDate, req.CheckoutDate)
+	if err != nil {
+		return nil, err
+	}
+	blockedIDs, err := service.blockedRoomIDs(req.HotelID, req.CheckinDate, req.CheckoutDate)
+	if err != nil {
+		return nil, err
+	}
+	ids = append(ids, blockedIDs...)
+
+	res = &models.MultiRoomSearchResponse{
+		HotelID:      req.HotelID,
+		CheckinDate:  req.CheckinDate,
+		CheckoutDate: req.CheckoutDate,
+		RoomQty:      roomQty,
+		Available:    true,
+		Lines:        make([]*models.MultiRoomLine, 0, len(req.Lines)),
+	}
+	for _, line := range req.Lines {
+		if _, err = service.repository.FindRoomTypeByID(line.RoomTypeID); err != nil {
+			return nil, err
+		}
+		rooms, err := service.repository.FindRooms(req.HotelID, line.RoomTypeID, ids)
+		if err != nil {
+			return nil, err
+		}
+		rooms = sellableRooms(rooms, req.CheckinDate, req.CheckoutDate)
+		policy, err := service.findCancellationPolicy(req.HotelID, line.RoomTypeID)
+		if err != nil {
+			return nil, err
+		}
+		prices, totalPrice, err := service.nightlyPrices(req.HotelID, line.RoomTypeID, req.CheckinDate, req.CheckoutDate)
+		if err != nil {
+			return nil, err
+		}
+		for _, room := range rooms {
+			room.Price = prices
+		}
+
+		result := &models.MultiRoomLine{
+			RoomTypeID:         line.RoomTypeID,
+			RoomQty:            line.RoomQty,
+			AvailableRoomCount: len(rooms),
+			Available:          len(rooms) >= line.RoomQty,
+			TotalPrice:         line.RoomQty * totalPrice,
+			CancellationPolicy: policy,
+			AvailableRooms:     rooms,
+		}
+		res.Available = res.Available && result.Available
+		res.TotalPrice = res.TotalPrice + result.TotalPrice
+		res.Lines = append(res.Lines, result)
+	}
+	return res, nil
+}
+
+//function to book every line of a multi room search, one reservation is created for each line and all of them are saved or none
+func (service *hotelMgmtService) CreateMultiRoomReservation(req *models.MultiRoomReservationRequest) (res *models.MultiRoomReservationResponse, err error) {
+	//re-check availability and prices of every line instead of trusting the request
+	search, err := service.FindMultiRoomAvailability(&models.MultiRoomSearchRequest{
+		HotelID:      req.HotelID,
+		CheckinDate:  req.CheckinDate,
+		CheckoutDate: req.CheckoutDate,
+		Lines:        req.Lines,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range search.Lines {
+		if !line.Available {
+			return nil, fmt.Errorf("%w: %d of %d rooms of room type %d are free", ErrNoAvailability, line.AvailableRoomCount, line.RoomQty, line.RoomTypeID)
+		}
+	}
+	dates, err := stayDates(req.CheckinDate, req.CheckoutDate)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations := make([]*models.Reservation, 0, len(search.Lines))
+	roomIDs := make([][]int, 0, len(search.Lines))
+	lineRooms := make([][]*models.Room, 0, len(search.Lines))
+	for _, line := range search.Lines {
+		rooms := pickRooms(line.AvailableRooms, nil, line.RoomQty)
+		ids := make([]int, 0, len(rooms))
+		for _, room := range rooms {
+			ids = append(ids, room.ID)
+		}
+		reservation := &models.Reservation{
+			Order: models.Order{
+				FinalPrice:      line.TotalPrice,
+				FirstNightPrice: firstNightPrice(rooms, line.RoomQty),
+			},
+			CustomerName:    req.CustomerName,
+			BookedRoomCount: line.RoomQty,
+			CheckinDate:     req.CheckinDate,
+			CheckoutDate:    req.CheckoutDate,
+			HotelID:         req.HotelID,
+			RoomTypeID:      line.RoomTypeID,
+		}
+		agreeCancellationPolicy(reservation, line.CancellationPolicy)
+		reservations = append(reservations, reservation)
+		roomIDs = append(roomIDs, ids)
+		lineRooms = append(lineRooms, rooms)
+	}
+	if err = service.repository.CreateReservations(reservations, models.OrderStatusPending, roomIDs, dates); err != nil {
+		return nil, err
+	}
+
+	//assign response model with processed data
+	res = &models.MultiRoomReservationResponse{
+		CustomerName: req.CustomerName,
+		HotelID:      req.HotelID,
+		CheckinDate:  req.CheckinDate,
+		CheckoutDate: req.CheckoutDate,
+		RoomQty:      search.RoomQty,
+		FinalPrice:   search.TotalPrice,
+		Reservations: make([]*models.ReservationResponse, 0, len(reservations)),
+	}
+	for i, reservation := range reservations {
+		res.Reservations = append(res.Reservations, &models.ReservationResponse{
+			ReservationID: reservation.ID,
+			OrderID:       reservation.OrderID,
+			OrderStatus:   reservation.Order.OrderStatus.Status,
+			CustomerName:  reservation.CustomerName,
+			HotelID:       reservation.HotelID,
+			RoomQty:       reservation.BookedRoomCount,
+			RoomTypeID:    reservation.RoomTypeID,
+			CheckinDate:   reservation.CheckinDate,
+			CheckoutDate:  reservation.CheckoutDate,
+			FinalPrice:    reservation.Order.FinalPrice,
+			Rooms:         lineRooms[i],
+		})
+	}
+	return res, nil
+}
+
+//check the lines of a multi room search and count their rooms, every room type can be listed once
+func validateRoomLines(lines []*models.RoomLine) (roomQty int, err error) {
+	invalid := &ValidationError{}
+	if len(lines) == 0 {
+		invalid.Add("lines", RuleRequired, "lines must have at least one room type")
+	}
+	listed := make(map[int]bool, len(lines))
+	for i, line := range lines {
+		field := fmt.Sprintf("lines[%d]", i)
+		if line == nil {
+			invalid.Add(field, RuleRequired, field+" is required")
+			continue
+		}
+		if line.RoomTypeID < 1 {
+			invalid.Add(field+".room_type_id", RuleRequired, field+".room_type_id is required")
+		} else if listed[line.RoomTypeID] {
+			invalid.Add(field+".room_type_id", RuleUnique, fmt.Sprintf("room type %d is listed more than once", line.RoomTypeID))
+		}
+		if line.RoomQty < 1 {
+			invalid.Add(field+".room_qty", RuleMin, field+".room_qty must be at least 1")
+		}
+		listed[line.RoomTypeID] = true
+		roomQty = roomQty + line.RoomQty
+	}
+	return roomQty, invalid.Err()
+}
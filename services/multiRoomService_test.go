@@ -0,0 +1,70 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+func TestValidateRoomLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		lines   []*models.RoomLine
+		roomQty int
+		fields  []string
+	}{
+		{name: "valid lines", lines: []*models.RoomLine{{RoomTypeID: 1, RoomQty: 2}, {RoomTypeID: 2, RoomQty: 1}}, roomQty: 3},
+		{name: "no lines", fields: []string{"lines"}},
+		{name: "room type listed twice", lines: []*models.RoomLine{{RoomTypeID: 1, RoomQty: 1}, {RoomTypeID: 1, RoomQty: 1}}, roomQty: 2, fields: []string{"lines[1].room_type_id"}},
+		{name: "missing room type and room qty", lines: []*models.RoomLine{{RoomQty: 1}, {RoomTypeID: 2}}, roomQty: 1, fields: []string{"lines[0].room_type_id", "lines[1].room_qty"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roomQty, err := validateRoomLines(tt.lines)
+			if roomQty != tt.roomQty {
+				t.Fatalf("expected %d rooms, got %d", tt.roomQty, roomQty)
+			}
+			if len(tt.fields) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var invalid *ValidationError
+			if !errors.As(err, &invalid) || len(invalid.Fields) != len(tt.fields) {
+				t.Fatalf("expected fields %v, got %v", tt.fields, err)
+			}
+			for i, field := range invalid.Fields {
+				if field.Field != tt.fields[i] {
+					t.Fatalf("expected field %s, got %s", tt.fields[i], field.Field)
+				}
+			}
+		})
+	}
+}
+
+func TestFindMultiRoomAvailability(t *testing.T) {
+	repo := newFakeRepo(3, "2022-12-05", 2, 100000)
+	repo.bookedRoomIDs = []int{1}
+	service := NewHotelMgmtServiceWithClock(repo, fixedClock(at("2022-12-01 10:00")))
+
+	req := &models.MultiRoomSearchRequest{HotelID: 1, CheckinDate: "2022-12-05", CheckoutDate: "2022-12-07", Lines: []*models.RoomLine{{RoomTypeID: 1, RoomQty: 2}}}
+	res, err := service.FindMultiRoomAvailability(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.Available || res.RoomQty != 2 || res.TotalPrice != 400000 || len(res.Lines) != 1 || res.Lines[0].AvailableRoomCount != 2 {
+		t.Fatalf("unexpected search result %+v", res)
+	}
+
+	//a line without enough free rooms is reported instead of failing the search
+	req.Lines[0].RoomQty = 3
+	if res, err = service.FindMultiRoomAvailability(req); err != nil || res.Available || res.Lines[0].Available || res.TotalPrice != 600000 {
+		t.Fatalf("expected unavailable line, got %+v (%v)", res, err)
+	}
+	_, err = service.CreateMultiRoomReservation(&models.MultiRoomReservationRequest{CustomerName: "family", HotelID: 1, CheckinDate: "2022-12-05", CheckoutDate: "2022-12-07", Lines: req.Lines})
+	if !errors.Is(err, ErrNoAvailability) {
+		t.Fatalf("expected no availability, got %v", err)
+	}
+}
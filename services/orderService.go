@@ -0,0 +1,85 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+	"github.com/nurcholisnanda/hotel-management-system/repositories"
+)
+
+var (
+	//ErrInvalidOrderTransition is returned when the order can't move from its status to the requested one
+	ErrInvalidOrderTransition = errors.New("invalid order status transition")
+	//ErrOrderStatusChanged is returned when another request changed the order status first, the request can be retried
+	ErrOrderStatusChanged = repositories.ErrOrderStatusChanged
+)
+
+//statuses each order status can move to, cancelled, checked-out and no-show are final
+var orderTransitions = map[string][]string{
+	models.OrderStatusPending:   {models.OrderStatusConfirmed, models.OrderStatusCancelled},
+	models.OrderStatusConfirmed: {models.OrderStatusCheckedIn, models.OrderStatusCancelled, models.OrderStatusNoShow},
+	models.OrderStatusCheckedIn: {models.OrderStatusCheckedOut},
+}
+
+//statuses that can be set directly, the other ones come with their own reservation flow
+var directOrderStatuses = map[string]bool{
+	models.OrderStatusConfirmed: true,
+	models.OrderStatusNoShow:    true,
+}
+
+//check order can move from one status to another
+func checkOrderTransition(from string, to string) error {
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: order can't move from %s to %s", ErrInvalidOrderTransition, from, to)
+}
+
+//check order is still open for changes
+func checkOrderOpen(status string) error {
+	if len(orderTransitions[status]) == 0 {
+		return fmt.Errorf("%w: order is already %s", ErrInvalidOrderTransition, status)
+	}
+	return nil
+}
+
+//function to move order to confirmed or no-show status, a no-show gives its rooms back to inventory
+func (service *hotelMgmtService) ChangeOrderStatus(orderID int, status string) (res *models.OrderHistoryResponse, err error) {
+	if !directOrderStatuses[status] {
+		return nil, fmt.Errorf("%w: use the reservation endpoints to move order to %s", ErrInvalidOrderTransition, status)
+	}
+	order, err := service.repository.FindOrderByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkOrderTransition(order.OrderStatus.Status, status); err != nil {
+		return nil, err
+	}
+	if err = service.repository.UpdateOrderStatus(order, status); err != nil {
+		return nil, err
+	}
+	return service.FindOrderHistory(orderID)
+}
+
+//function to list every status change of an order
+func (service *hotelMgmtService) FindOrderHistory(orderID int) (res *models.OrderHistoryResponse, err error) {
+	order, err := service.repository.FindOrderByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := service.repository.FindOrderHistory(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	//assign response model with processed data
+	res = &models.OrderHistoryResponse{
+		OrderID:     order.ID,
+		OrderStatus: order.OrderStatus.Status,
+		History:     history,
+	}
+	return res, nil
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+func TestCheckOrderTransition(t *testing.T) {
+	tests := []struct {
+		from  string
+		to    string
+		valid bool
+	}{
+		{models.OrderStatusPending, models.OrderStatusConfirmed, true},
+		{models.OrderStatusPending, models.OrderStatusCancelled, true},
+		{models.OrderStatusPending, models.OrderStatusCheckedIn, false},
+		{models.OrderStatusConfirmed, models.OrderStatusCheckedIn, true},
+		{models.OrderStatusConfirmed, models.OrderStatusNoShow, true},
+		{models.OrderStatusConfirmed, models.OrderStatusPending, false},
+		{models.OrderStatusCheckedIn, models.OrderStatusCheckedOut, true},
+		{models.OrderStatusCheckedIn, models.OrderStatusCancelled, false},
+		{models.OrderStatusCheckedOut, models.OrderStatusCheckedIn, false},
+		{models.OrderStatusCancelled, models.OrderStatusConfirmed, false},
+		{models.OrderStatusNoShow, models.OrderStatusCheckedIn, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			err := checkOrderTransition(tt.from, tt.to)
+			if tt.valid && err != nil {
+				t.Fatalf("expected valid transition, got %v", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidOrderTransition) {
+				t.Fatalf("expected invalid transition error, got %v", err)
+			}
+		})
+	}
+}
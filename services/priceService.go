@@ -0,0 +1,300 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+var (
+	//ErrInvalidPrice is returned when a base rate can't be used for the rate calendar
+	ErrInvalidPrice = errors.New("invalid price")
+	//ErrRateNotLoaded is returned when a night of the stay has no price and the room type has no base rate
+	ErrRateNotLoaded = errors.New("rate not loaded")
+)
+
+//MissingRatesError lists the nights of a stay which have no price, it matches ErrRateNotLoaded
+type MissingRatesError struct {
+	HotelID      int
+	RoomTypeID   int
+	MissingDates []string
+}
+
+func (err *MissingRatesError) Error() string {
+	return fmt.Sprintf("%s for room type %d of hotel %d on %s", ErrRateNotLoaded.Error(), err.RoomTypeID, err.HotelID, strings.Join(err.MissingDates, ", "))
+}
+
+func (err *MissingRatesError) Is(target error) bool {
+	return target == ErrRateNotLoaded
+}
+
+//longest date range of the rate calendar handled at once
+const maxPriceRangeNights = 731
+
+//day of week names accepted by price overrides
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+//function to list nightly prices of a room type with the nights that have no price, from today for a year when dates are empty
+func (service *hotelMgmtService) FindPriceCalendar(hotelID int, roomTypeID int, fromDate string, toDate string) (res *models.PriceCalendarResponse, err error) {
+	if err = service.checkPriceTarget(hotelID, roomTypeID); err != nil {
+		return nil, err
+	}
+	if fromDate == "" {
+		now, err := service.hotelTime(hotelID)
+		if err != nil {
+			return nil, err
+		}
+		fromDate = now.Format(dateForm)
+	}
+	if toDate == "" {
+		from, err := time.Parse(dateForm, fromDate)
+		if err != nil {
+			invalid := &ValidationError{}
+			invalid.Add("from_date", RuleFormat, fmt.Sprintf("from_date must look like %s", dateForm))
+			return nil, invalid.Err()
+		}
+		toDate = from.AddDate(1, 0, 0).Format(dateForm)
+	}
+	dates, err := priceDates("from_date", fromDate, "to_date", toDate)
+	if err != nil {
+		return nil, err
+	}
+	prices, err := service.repository.FindPrices(hotelID, roomTypeID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	//assign response model with processed data
+	res = &models.PriceCalendarResponse{
+		HotelID:      hotelID,
+		RoomTypeID:   roomTypeID,
+		FromDate:     fromDate,
+		ToDate:       toDate,
+		Prices:       prices,
+		MissingDates: missingPriceDates(prices, dates),
+	}
+	return res, nil
+}
+
+//function to set one price for every night of a date range, nights on the given days of week get their own price
+func (service *hotelMgmtService) SetPriceRange(hotelID int, roomTypeID int, req *models.PriceRangeRequest) (res *models.PriceCalendarResponse, err error) {
+	if err = service.checkPriceTarget(hotelID, roomTypeID); err != nil {
+		return nil, err
+	}
+	dates, err := priceDates("from_date", req.FromDate, "to_date", req.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	invalid := &ValidationError{}
+	if req.Price < 1 {
+		invalid.Add("price", RuleMin, "price must be greater than 0")
+	}
+	overrides := make(map[time.Weekday]int, len(req.DayOfWeekPrices))
+	for day, price := range req.DayOfWeekPrices {
+		field := "day_of_week_prices." + day
+		weekday, ok := weekdays[strings.ToLower(day)]
+		if !ok {
+			invalid.Add(field, RuleFormat, fmt.Sprintf("unknown day of week %q", day))
+			continue
+		}
+		if price < 1 {
+			invalid.Add(field, RuleMin, fmt.Sprintf("price of %s must be greater than 0", day))
+		}
+		overrides[weekday] = price
+	}
+	if err = invalid.Err(); err != nil {
+		return nil, err
+	}
+
+	prices := make([]*models.Price, 0, len(dates))
+	for _, date := range dates {
+		day, _ := time.Parse(dateForm, date)
+		price, ok := overrides[day.Weekday()]
+		if !ok {
+			price = req.Price
+		}
+		prices = append(prices, &models.Price{Date: date, Price: price})
+	}
+	if err = service.repository.SavePrices(hotelID, roomTypeID, prices); err != nil {
+		return nil, err
+	}
+	return service.FindPriceCalendar(hotelID, roomTypeID, req.FromDate, req.ToDate)
+}
+
+//function to load nightly prices from a csv file with date and price columns, the whole file is rejected when a line is invalid,
+//the validation error names the field of every invalid line like lines[3].price
+func (service *hotelMgmtService) ImportPrices(hotelID int, roomTypeID int, file io.Reader) (res *models.PriceCalendarResponse, err error) {
+	if err = service.checkPriceTarget(hotelID, roomTypeID); err != nil {
+		return nil, err
+	}
+	invalid := &ValidationError{}
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil || strings.ToLower(header[0]) != "date" || strings.ToLower(header[1]) != "price" {
+		invalid.Add("lines[1]", RuleFormat, "line 1: first line must be the header date,price")
+		return nil, invalid.Err()
+	}
+
+	var prices []*models.Price
+	seen := make(map[string]int)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			//a line that can't be read as csv stops the import, the lines after it can't be trusted
+			invalid.Add(fmt.Sprintf("lines[%d]", line), RuleFormat, fmt.Sprintf("line %d: %v", line, err))
+			break
+		}
+		field := fmt.Sprintf("lines[%d]", line)
+		if _, err = time.Parse(dateForm, record[0]); err != nil {
+			invalid.Add(field+".date", RuleFormat, fmt.Sprintf("line %d: date %q must look like %s", line, record[0], dateForm))
+		} else if previous, ok := seen[record[0]]; ok {
+			invalid.Add(field+".date", RuleUnique, fmt.Sprintf("line %d: date %s is already priced on line %d", line, record[0], previous))
+		} else {
+			seen[record[0]] = line
+		}
+		price, err := strconv.Atoi(record[1])
+		if err != nil || price < 1 {
+			invalid.Add(field+".price", RuleMin, fmt.Sprintf("line %d: price %q must be a number greater than 0", line, record[1]))
+		}
+		prices = append(prices, &models.Price{Date: record[0], Price: price})
+	}
+	if err = invalid.Err(); err != nil {
+		return nil, err
+	}
+	if len(prices) == 0 {
+		invalid.Add("lines", RuleRequired, "file has no prices")
+		return nil, invalid.Err()
+	}
+
+	//the file covers the nights from its first date until its last date
+	sort.Slice(prices, func(i, j int) bool { return prices[i].Date < prices[j].Date })
+	last, _ := time.Parse(dateForm, prices[len(prices)-1].Date)
+	fromDate, toDate := prices[0].Date, last.AddDate(0, 0, 1).Format(dateForm)
+	if _, err = priceDates("lines", fromDate, "lines", toDate); err != nil {
+		return nil, err
+	}
+	if err = service.repository.SavePrices(hotelID, roomTypeID, prices); err != nil {
+		return nil, err
+	}
+	return service.FindPriceCalendar(hotelID, roomTypeID, fromDate, toDate)
+}
+
+//function to write nightly prices as a csv file with date and price columns, the file can be imported again
+func (service *hotelMgmtService) ExportPrices(hotelID int, roomTypeID int, fromDate string, toDate string, file io.Writer) error {
+	calendar, err := service.FindPriceCalendar(hotelID, roomTypeID, fromDate, toDate)
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(file)
+	writer.Write([]string{"date", "price"})
+	for _, price := range calendar.Prices {
+		writer.Write([]string{price.Date[0:10], strconv.Itoa(price.Price)})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+//price of every night between checkin date and checkout date, nights without price take the base rate of the room type
+func (service *hotelMgmtService) nightlyPrices(hotelID int, roomTypeID int, checkinDate string, checkoutDate string) (prices []*models.Price, totalPrice int, err error) {
+	dates, err := stayDates(checkinDate, checkoutDate)
+	if err != nil {
+		return nil, 0, err
+	}
+	found, err := service.repository.FindPrices(hotelID, roomTypeID, checkinDate, checkoutDate)
+	if err != nil {
+		return nil, 0, err
+	}
+	pricesByDate := make(map[string]*models.Price, len(found))
+	for _, price := range found {
+		price.Date = price.Date[0:10]
+		pricesByDate[price.Date] = price
+	}
+
+	var missingDates []string
+	for _, date := range dates {
+		if pricesByDate[date] == nil {
+			missingDates = append(missingDates, date)
+		}
+	}
+	baseRate := 0
+	if len(missingDates) > 0 {
+		roomType, err := service.repository.FindRoomTypeByID(roomTypeID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if roomType.BaseRate <= 0 {
+			return nil, 0, &MissingRatesError{HotelID: hotelID, RoomTypeID: roomTypeID, MissingDates: missingDates}
+		}
+		baseRate = roomType.BaseRate
+	}
+
+	prices = make([]*models.Price, 0, len(dates))
+	for _, date := range dates {
+		price := pricesByDate[date]
+		if price == nil {
+			price = &models.Price{Date: date, HotelID: hotelID, RoomTypeID: roomTypeID, Price: baseRate, Fallback: true}
+		}
+		totalPrice = totalPrice + price.Price
+		prices = append(prices, price)
+	}
+	return prices, totalPrice, nil
+}
+
+//check hotel and room type of the rate calendar exist
+func (service *hotelMgmtService) checkPriceTarget(hotelID int, roomTypeID int) error {
+	if _, err := service.repository.FindHotelByID(hotelID); err != nil {
+		return err
+	}
+	_, err := service.repository.FindRoomTypeByID(roomTypeID)
+	return err
+}
+
+//list every night from the from date until the night before the to date, ranges longer than two years are refused
+func priceDates(fromField string, fromDate string, toField string, toDate string) ([]string, error) {
+	from, to, err := dateRange(fromField, fromDate, toField, toDate)
+	if err != nil {
+		return nil, err
+	}
+	if to.Sub(from) > maxPriceRangeNights*24*time.Hour {
+		invalid := &ValidationError{}
+		invalid.Add(toField, RuleMax, fmt.Sprintf("date range can't be longer than %d nights", maxPriceRangeNights))
+		return nil, invalid.Err()
+	}
+	return stayDates(fromDate, toDate)
+}
+
+//list nights which have no price
+func missingPriceDates(prices []*models.Price, dates []string) []string {
+	priced := make(map[string]bool, len(prices))
+	for _, price := range prices {
+		price.Date = price.Date[0:10]
+		priced[price.Date] = true
+	}
+	missing := []string{}
+	for _, date := range dates {
+		if !priced[date] {
+			missing = append(missing, date)
+		}
+	}
+	return missing
+}
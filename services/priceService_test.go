@@ -0,0 +1,128 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+func TestSetPriceRangeWithDayOfWeekPrices(t *testing.T) {
+	repo := newFakeRepo(1, "2022-12-01", 0, 0)
+	service := NewHotelMgmtServiceWithClock(repo, fixedClock(at("2022-11-01 10:00")))
+
+	//2022-12-02 is a friday and 2022-12-03 a saturday
+	calendar, err := service.SetPriceRange(1, 1, &models.PriceRangeRequest{
+		FromDate:        "2022-12-01",
+		ToDate:          "2022-12-05",
+		Price:           100000,
+		DayOfWeekPrices: map[string]int{"Friday": 120000, "saturday": 150000},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []int{100000, 120000, 150000, 100000}
+	if len(calendar.Prices) != len(want) || len(calendar.MissingDates) != 0 {
+		t.Fatalf("expected 4 prices and no missing dates, got %+v", calendar)
+	}
+	for i, price := range calendar.Prices {
+		if price.Price != want[i] {
+			t.Fatalf("night %s: expected price %d, got %d", price.Date, want[i], price.Price)
+		}
+	}
+
+	_, err = service.SetPriceRange(1, 1, &models.PriceRangeRequest{FromDate: "2022-12-01", ToDate: "2022-12-05", Price: 100000, DayOfWeekPrices: map[string]int{"funday": 1}})
+	if field := invalidField(err); field != "day_of_week_prices.funday" {
+		t.Fatalf("expected validation error for unknown day, got %v", err)
+	}
+	_, err = service.SetPriceRange(1, 1, &models.PriceRangeRequest{FromDate: "2022-12-01", ToDate: "2025-12-01", Price: 100000})
+	if field := invalidField(err); field != "to_date" {
+		t.Fatalf("expected validation error for a range over two years, got %v", err)
+	}
+}
+
+func TestImportPrices(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		wantField string
+	}{
+		{"missing header", "2022-12-01,100000\n", "lines[1]"},
+		{"bad date", "date,price\n2022-12-01,100000\n01/12/2022,100000\n", "lines[3].date"},
+		{"bad price", "date,price\n2022-12-01,free\n", "lines[2].price"},
+		{"duplicate date", "date,price\n2022-12-01,100000\n2022-12-01,110000\n", "lines[3].date"},
+		{"missing column", "date,price\n2022-12-01\n", "lines[2]"},
+		{"no prices", "date,price\n", "lines"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepo(1, "2022-12-01", 0, 0)
+			service := NewHotelMgmtServiceWithClock(repo, fixedClock(at("2022-11-01 10:00")))
+			_, err := service.ImportPrices(1, 1, strings.NewReader(tt.file))
+			if field := invalidField(err); field != tt.wantField {
+				t.Fatalf("expected validation error of %s, got %v", tt.wantField, err)
+			}
+			if len(repo.prices) != 0 {
+				t.Fatalf("expected nothing saved, got %d prices", len(repo.prices))
+			}
+		})
+	}
+
+	//exported prices can be imported again
+	repo := newFakeRepo(1, "2022-12-01", 0, 0)
+	service := NewHotelMgmtServiceWithClock(repo, fixedClock(at("2022-11-01 10:00")))
+	calendar, err := service.ImportPrices(1, 1, strings.NewReader("date,price\n2022-12-03,130000\n2022-12-01,100000\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calendar.FromDate != "2022-12-01" || calendar.ToDate != "2022-12-04" || len(calendar.MissingDates) != 1 || calendar.MissingDates[0] != "2022-12-02" {
+		t.Fatalf("expected 2022-12-02 to be missing, got %+v", calendar)
+	}
+	var file bytes.Buffer
+	if err = service.ExportPrices(1, 1, "2022-12-01", "2022-12-04", &file); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "date,price\n2022-12-01,100000\n2022-12-03,130000\n"; file.String() != want {
+		t.Fatalf("expected export %q, got %q", want, file.String())
+	}
+}
+
+func TestFindAvailableRoomsWithMissingPrice(t *testing.T) {
+	repo := newFakeRepo(2, "2022-12-09", 3, 100000)
+	repo.prices = append(repo.prices[:1], repo.prices[2])
+	service := NewHotelMgmtServiceWithClock(repo, fixedClock(at("2022-12-05 10:00")))
+
+	_, err := service.FindAvailableRooms(1, "2022-12-09", "2022-12-12", 1, 1)
+	var missingRates *MissingRatesError
+	if !errors.As(err, &missingRates) || !errors.Is(err, ErrRateNotLoaded) {
+		t.Fatalf("expected missing rates error, got %v", err)
+	}
+	if len(missingRates.MissingDates) != 1 || missingRates.MissingDates[0] != "2022-12-10" {
+		t.Fatalf("expected 2022-12-10 to be missing, got %v", missingRates.MissingDates)
+	}
+
+	//the base rate of the room type fills the missing night
+	repo.baseRate = 80000
+	availableRooms, err := service.FindAvailableRooms(1, "2022-12-09", "2022-12-12", 2, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if availableRooms.TotalPrice != 2*280000 {
+		t.Fatalf("expected total price %d, got %d", 2*280000, availableRooms.TotalPrice)
+	}
+	prices := availableRooms.AvailableRooms[0].Price
+	if len(prices) != 3 || !prices[1].Fallback || prices[1].Date != "2022-12-10" || prices[0].Fallback {
+		t.Fatalf("expected the second night to use the base rate, got %+v", prices)
+	}
+}
+
+//field of the first rule broken by a validation error, empty for other errors
+func invalidField(err error) string {
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) {
+		return ""
+	}
+	return invalid.Fields[0].Field
+}
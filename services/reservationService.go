@@ -0,0 +1,146 @@
+package services
+
+import (
+	"github.com/nurcholisnanda/hotel-management-system/models"
+	"github.com/nurcholisnanda/hotel-management-system/repositories"
+)
+
+const dateForm = "2006-01-02"
+
+var (
+	//ErrRoomUnavailable is returned when another reservation took the room nights first, the request can be retried
+	ErrRoomUnavailable = repositories.ErrRoomUnavailable
+	//ErrPromoFullyRedeemed is returned when the promo has no redemptions left
+	ErrPromoFullyRedeemed = repositories.ErrPromoFullyRedeemed
+	//ErrPromoCustomerLimit is returned when the customer already used the promo as often as allowed
+	ErrPromoCustomerLimit = repositories.ErrPromoCustomerLimit
+)
+
+//function to create reservation from available rooms or promo rooms result
+func (service *hotelMgmtService) CreateReservation(req *models.ReservationRequest) (res *models.ReservationResponse, err error) {
+	//re-check availability and prices instead of trusting the request
+	availableRooms, err := service.FindAvailableRooms(req.HotelID, req.CheckinDate, req.CheckoutDate, req.RoomQty, req.RoomTypeID)
+	if err != nil {
+		return nil, err
+	}
+	dates, err := stayDates(req.CheckinDate, req.CheckoutDate)
+	if err != nil {
+		return nil, err
+	}
+	rooms := pickRooms(availableRooms.AvailableRooms, req.AvailableRooms, req.RoomQty)
+	finalPrice := availableRooms.TotalPrice
+	firstNight := firstNightPrice(availableRooms.AvailableRooms, req.RoomQty)
+	var promoRooms *models.PromoRoomsResponse
+	var promoIDs []int
+
+	//apply promo price when reservation is made from promo rooms
+	if req.PromoID != 0 || req.PromoCode != "" || len(req.PromoIDs) > 0 || len(req.PromoCodes) > 0 {
+		promoRooms, err = service.FindPromoRooms(&models.PromoRoomsRequest{
+			PromoID:      req.PromoID,
+			PromoCode:    req.PromoCode,
+			PromoIDs:     req.PromoIDs,
+			PromoCodes:   req.PromoCodes,
+			HotelID:      req.HotelID,
+			RoomQty:      req.RoomQty,
+			RoomTypeID:   req.RoomTypeID,
+			CheckinDate:  req.CheckinDate,
+			CheckoutDate: req.CheckoutDate,
+		})
+		if err != nil {
+			return nil, err
+		}
+		finalPrice = promoRooms.TotalPrice
+		firstNight = firstNightPrice(promoRooms.AvailableRooms, req.RoomQty)
+		for _, promo := range promoRooms.Promos {
+			promoIDs = append(promoIDs, promo.PromoID)
+		}
+	}
+
+	roomIDs := make([]int, 0, len(rooms))
+	for _, room := range rooms {
+		roomIDs = append(roomIDs, room.ID)
+	}
+
+	reservation := &models.Reservation{
+		Order: models.Order{
+			FinalPrice:      finalPrice,
+			FirstNightPrice: firstNight,
+		},
+		CustomerName:    req.CustomerName,
+		BookedRoomCount: req.RoomQty,
+		CheckinDate:     req.CheckinDate,
+		CheckoutDate:    req.CheckoutDate,
+		HotelID:         req.HotelID,
+		RoomTypeID:      req.RoomTypeID,
+		PromoIDs:        promoIDs,
+	}
+	agreeCancellationPolicy(reservation, availableRooms.CancellationPolicy)
+	if err = service.repository.CreateReservation(reservation, models.OrderStatusPending, roomIDs, dates); err != nil {
+		return nil, err
+	}
+
+	//assign response model with processed data
+	res = &models.ReservationResponse{
+		ReservationID: reservation.ID,
+		OrderID:       reservation.OrderID,
+		OrderStatus:   reservation.Order.OrderStatus.Status,
+		CustomerName:  reservation.CustomerName,
+		HotelID:       reservation.HotelID,
+		RoomQty:       req.RoomQty,
+		RoomTypeID:    req.RoomTypeID,
+		CheckinDate:   req.CheckinDate,
+		CheckoutDate:  req.CheckoutDate,
+		FinalPrice:    finalPrice,
+		Rooms:         rooms,
+	}
+	if promoRooms != nil {
+		res.PromoID = promoRooms.PromoID
+		res.PromoCode = promoRooms.PromoCode
+		res.Promos = promoRooms.Promos
+	}
+	return res, nil
+}
+
+//list every night between checkin date and checkout date
+func stayDates(checkinDate string, checkoutDate string) ([]string, error) {
+	checkin, checkout, err := dateRange("checkin_date", checkinDate, "checkout_date", checkoutDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var dates []string
+	for day := checkin; day.Before(checkout); day = day.AddDate(0, 0, 1) {
+		dates = append(dates, day.Format(dateForm))
+	}
+	return dates, nil
+}
+
+//pick requested rooms first when they are still available, then fill up from the rest
+func pickRooms(available []*models.Room, requested []*models.Room, qty int) []*models.Room {
+	picked := make([]*models.Room, 0, qty)
+	used := make(map[int]bool)
+
+	availableByID := make(map[int]*models.Room, len(available))
+	for _, room := range available {
+		availableByID[room.ID] = room
+	}
+	for _, room := range requested {
+		if len(picked) == qty {
+			return picked
+		}
+		if found, ok := availableByID[room.ID]; ok && !used[room.ID] {
+			picked = append(picked, found)
+			used[room.ID] = true
+		}
+	}
+	for _, room := range available {
+		if len(picked) == qty {
+			return picked
+		}
+		if !used[room.ID] {
+			picked = append(picked, room)
+			used[room.ID] = true
+		}
+	}
+	return picked
+}
@@ -0,0 +1,252 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+//function to change dates, room count or room type of a reservation, keeping the room nights it already holds
+func (service *hotelMgmtService) UpdateReservation(id int, req *models.ReservationUpdateRequest) (res *models.ReservationUpdateResponse, err error) {
+	reservation, err := service.repository.FindReservationByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if reservation.Order.OrderStatus.Status == models.OrderStatusCancelled {
+		return nil, ErrReservationCancelled
+	}
+	if err = checkOrderOpen(reservation.Order.OrderStatus.Status); err != nil {
+		return nil, err
+	}
+	redemptions, err := service.repository.FindPromoRedemptions(id)
+	if err != nil {
+		return nil, err
+	}
+
+	old := &models.ReservationCharge{
+		RoomQty:      reservation.BookedRoomCount,
+		RoomTypeID:   reservation.RoomTypeID,
+		CheckinDate:  reservation.CheckinDate[0:10],
+		CheckoutDate: reservation.CheckoutDate[0:10],
+		FinalPrice:   reservation.Order.FinalPrice,
+	}
+	for _, redemption := range redemptions {
+		old.PromoIDs = append(old.PromoIDs, redemption.PromoID)
+	}
+
+	//fields left empty keep their booked value
+	updated := &models.ReservationCharge{
+		RoomQty:      old.RoomQty,
+		RoomTypeID:   old.RoomTypeID,
+		CheckinDate:  old.CheckinDate,
+		CheckoutDate: old.CheckoutDate,
+	}
+	if req.RoomQty != 0 {
+		updated.RoomQty = req.RoomQty
+	}
+	if req.RoomTypeID != 0 {
+		updated.RoomTypeID = req.RoomTypeID
+	}
+	if req.CheckinDate != "" {
+		updated.CheckinDate = req.CheckinDate
+	}
+	if req.CheckoutDate != "" {
+		updated.CheckoutDate = req.CheckoutDate
+	}
+	if err = service.validateStay(reservation.HotelID, updated.CheckinDate, updated.CheckoutDate, updated.RoomQty, updated.CheckinDate == old.CheckinDate); err != nil {
+		return nil, err
+	}
+	if updated.RoomTypeID == 0 {
+		invalid := &ValidationError{}
+		invalid.Add("room_type_id", RuleRequired, "room_type_id is required to modify a reservation booked without room type")
+		return nil, invalid
+	}
+	dates, err := stayDates(updated.CheckinDate, updated.CheckoutDate)
+	if err != nil {
+		return nil, err
+	}
+
+	//fetching nights held by the reservation and nights sold to other reservations
+	held, err := service.repository.FindReservationStayRooms(id)
+	if err != nil {
+		return nil, err
+	}
+	sold, err := service.repository.FindStayRooms(reservation.HotelID, updated.CheckinDate, updated.CheckoutDate)
+	if err != nil {
+		return nil, err
+	}
+	rooms, err := service.repository.FindRooms(reservation.HotelID, updated.RoomTypeID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	heldNights := make(map[int]map[string]*models.StayRoom)
+	heldStays := make(map[int]int)
+	var heldRoomIDs []int
+	for _, night := range held {
+		if heldNights[night.RoomID] == nil {
+			heldNights[night.RoomID] = make(map[string]*models.StayRoom)
+			heldStays[night.RoomID] = night.StayID
+			heldRoomIDs = append(heldRoomIDs, night.RoomID)
+		}
+		heldNights[night.RoomID][night.Date[0:10]] = night
+	}
+	soldToOthers := make(map[int]bool)
+	for _, night := range sold {
+		if heldStays[night.RoomID] != night.StayID {
+			soldToOthers[night.RoomID] = true
+		}
+	}
+	blockedIDs, err := service.blockedRoomIDs(reservation.HotelID, updated.CheckinDate, updated.CheckoutDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, roomID := range blockedIDs {
+		soldToOthers[roomID] = true
+	}
+	rooms = sellableRooms(rooms, updated.CheckinDate, updated.CheckoutDate)
+	roomsByID := make(map[int]*models.Room, len(rooms))
+	for _, room := range rooms {
+		roomsByID[room.ID] = room
+	}
+
+	//keep held rooms of the requested type which are free for the new nights, then fill up with free rooms
+	picked := make([]*models.Room, 0, updated.RoomQty)
+	kept := make(map[int]bool)
+	for _, roomID := range heldRoomIDs {
+		if room, ok := roomsByID[roomID]; ok && !soldToOthers[roomID] && len(picked) < updated.RoomQty {
+			picked = append(picked, room)
+			kept[roomID] = true
+		}
+	}
+	for _, room := range rooms {
+		if len(picked) == updated.RoomQty {
+			break
+		}
+		if _, ok := heldStays[room.ID]; !ok && !soldToOthers[room.ID] {
+			picked = append(picked, room)
+		}
+	}
+	if len(picked) < updated.RoomQty {
+		return nil, fmt.Errorf("%w: %d of %d rooms are free", ErrNoAvailability, len(picked), updated.RoomQty)
+	}
+
+	//only the difference between held nights and new nights is released or sold
+	newDates := make(map[string]bool, len(dates))
+	for _, date := range dates {
+		newDates[date] = true
+	}
+	var releaseNights, holdNights []*models.StayRoom
+	for _, night := range held {
+		if !kept[night.RoomID] || !newDates[night.Date[0:10]] {
+			releaseNights = append(releaseNights, night)
+		}
+	}
+	for _, room := range picked {
+		for _, date := range dates {
+			if heldNights[room.ID][date] == nil {
+				holdNights = append(holdNights, &models.StayRoom{
+					StayID: heldStays[room.ID],
+					RoomID: room.ID,
+					Date:   date,
+				})
+			}
+		}
+	}
+
+	//re-price every night from the price list
+	prices, totalPrice, err := service.nightlyPrices(reservation.HotelID, updated.RoomTypeID, updated.CheckinDate, updated.CheckoutDate)
+	if err != nil {
+		return nil, err
+	}
+	for i, room := range picked {
+		copied := *room
+		copied.Price = prices
+		picked[i] = &copied
+	}
+	availableRooms := &models.HotelAvailableRoomsResponse{
+		HotelID:        reservation.HotelID,
+		RoomQty:        updated.RoomQty,
+		RoomTypeID:     updated.RoomTypeID,
+		CheckinDate:    updated.CheckinDate,
+		CheckoutDate:   updated.CheckoutDate,
+		TotalPrice:     updated.RoomQty * totalPrice,
+		AvailableRooms: picked,
+	}
+	updated.FinalPrice = availableRooms.TotalPrice
+
+	//keep the original promos the new stay still qualifies for, judged at the time they were booked
+	var removedPromoIDs []int
+	if len(redemptions) > 0 {
+		now, err := service.hotelTime(reservation.HotelID)
+		if err != nil {
+			return nil, err
+		}
+		bookingTime := redemptions[0].CreatedAt.In(now.Location())
+
+		var promos []*models.Promo
+		for _, redemption := range redemptions {
+			promo, err := service.repository.FindPromoByID(redemption.PromoID)
+			if err != nil {
+				return nil, err
+			}
+			//the redemption of this reservation doesn't count against the promo limit
+			copied := *promo
+			if copied.RedemptionCount > 0 {
+				copied.RedemptionCount--
+			}
+			if _, err = service.checkPromoRules(&copied, availableRooms, bookingTime); err != nil {
+				removedPromoIDs = append(removedPromoIDs, promo.ID)
+				continue
+			}
+			promos = append(promos, &copied)
+		}
+		if len(promos) > 0 {
+			promoRooms, err := service.applyPromos(promos, availableRooms, bookingTime)
+			if err != nil {
+				return nil, err
+			}
+			updated.FinalPrice = promoRooms.TotalPrice
+			picked = promoRooms.AvailableRooms
+			for _, promo := range promoRooms.Promos {
+				updated.PromoIDs = append(updated.PromoIDs, promo.PromoID)
+			}
+		}
+	}
+
+	reservation.BookedRoomCount = updated.RoomQty
+	reservation.RoomTypeID = updated.RoomTypeID
+	reservation.CheckinDate = updated.CheckinDate
+	reservation.CheckoutDate = updated.CheckoutDate
+	reservation.Order.FinalPrice = updated.FinalPrice
+	reservation.Order.FirstNightPrice = firstNightPrice(picked, updated.RoomQty)
+	if err = service.repository.UpdateReservation(reservation, releaseNights, holdNights, removedPromoIDs); err != nil {
+		return nil, err
+	}
+
+	//assign response model with processed data
+	res = &models.ReservationUpdateResponse{
+		ReservationID:   reservation.ID,
+		OrderID:         reservation.OrderID,
+		Old:             old,
+		New:             updated,
+		PriceDifference: updated.FinalPrice - old.FinalPrice,
+		AddedNights:     roomNights(holdNights),
+		ReleasedNights:  roomNights(releaseNights),
+		RemovedPromoIDs: removedPromoIDs,
+		Rooms:           picked,
+	}
+	return res, nil
+}
+
+//list room and date of stay rooms
+func roomNights(stayRooms []*models.StayRoom) []*models.RoomNight {
+	nights := make([]*models.RoomNight, 0, len(stayRooms))
+	for _, stayRoom := range stayRooms {
+		nights = append(nights, &models.RoomNight{
+			RoomID: stayRoom.RoomID,
+			Date:   stayRoom.Date[0:10],
+		})
+	}
+	return nights
+}
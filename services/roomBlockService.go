@@ -0,0 +1,205 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+//ErrRoomBlockOverlap is returned when the room already has a block for some of the dates
+var ErrRoomBlockOverlap = errors.New("room is already blocked for these dates")
+
+//RoomBlockConflictError lists the sold room nights that stop a room from being blocked, it matches ErrRoomBooked
+type RoomBlockConflictError struct {
+	Conflicts []*models.RoomNight
+}
+
+func (err *RoomBlockConflictError) Error() string {
+	return fmt.Sprintf("%s: %d sold room nights overlap the block", ErrRoomBooked.Error(), len(err.Conflicts))
+}
+
+func (err *RoomBlockConflictError) Is(target error) bool {
+	return target == ErrRoomBooked
+}
+
+//function to list room blocks of a hotel overlapping from date until to date
+func (service *hotelMgmtService) FindRoomBlocks(hotelID int, fromDate string, toDate string) (blocks []*models.RoomBlock, err error) {
+	fromDate, toDate, err = service.reportDates(hotelID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	return service.repository.FindRoomBlocks(hotelID, fromDate, toDate)
+}
+
+//function to find room block by id
+func (service *hotelMgmtService) FindRoomBlock(id int) (block *models.RoomBlock, err error) {
+	return service.repository.FindRoomBlockByID(id)
+}
+
+//function to block a room for maintenance
+func (service *hotelMgmtService) CreateRoomBlock(req *models.RoomBlockRequest) (block *models.RoomBlock, err error) {
+	block = &models.RoomBlock{
+		RoomID:    req.RoomID,
+		FromDate:  req.FromDate,
+		UntilDate: req.UntilDate,
+		Reason:    req.Reason,
+	}
+	if err = service.validateRoomBlock(block); err != nil {
+		return nil, err
+	}
+	if err = service.repository.CreateRoomBlock(block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+//function to change room, dates or reason of a room block
+func (service *hotelMgmtService) UpdateRoomBlock(id int, req *models.RoomBlockRequest) (block *models.RoomBlock, err error) {
+	block, err = service.repository.FindRoomBlockByID(id)
+	if err != nil {
+		return nil, err
+	}
+	block.RoomID = req.RoomID
+	block.FromDate = req.FromDate
+	block.UntilDate = req.UntilDate
+	block.Reason = req.Reason
+	if err = service.validateRoomBlock(block); err != nil {
+		return nil, err
+	}
+	if err = service.repository.UpdateRoomBlock(block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+//function to remove room block, the room can be sold again
+func (service *hotelMgmtService) DeleteRoomBlock(id int) error {
+	return service.repository.DeleteRoomBlock(id)
+}
+
+//function to list every blocked room night of a hotel between from date and to date
+func (service *hotelMgmtService) FindRoomBlockReport(hotelID int, fromDate string, toDate string) (res *models.RoomBlockReportResponse, err error) {
+	fromDate, toDate, err = service.reportDates(hotelID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := service.repository.FindRoomBlocks(hotelID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	rooms, err := service.repository.FindHotelRooms(hotelID)
+	if err != nil {
+		return nil, err
+	}
+	roomNumbers := make(map[int]int, len(rooms))
+	for _, room := range rooms {
+		roomNumbers[room.ID] = room.RoomNumber
+	}
+
+	//count only the nights of each block inside the report dates
+	res = &models.RoomBlockReportResponse{
+		HotelID:  hotelID,
+		FromDate: fromDate,
+		ToDate:   toDate,
+		Nights:   []*models.BlockedRoomNight{},
+	}
+	for _, block := range blocks {
+		from, until := block.FromDate[0:10], block.UntilDate[0:10]
+		if from < fromDate {
+			from = fromDate
+		}
+		if until > toDate {
+			until = toDate
+		}
+		dates, err := stayDates(from, until)
+		if err != nil {
+			return nil, err
+		}
+		for _, date := range dates {
+			res.Nights = append(res.Nights, &models.BlockedRoomNight{
+				BlockID:    block.ID,
+				RoomID:     block.RoomID,
+				RoomNumber: roomNumbers[block.RoomID],
+				Date:       date,
+				Reason:     block.Reason,
+			})
+		}
+	}
+	sort.SliceStable(res.Nights, func(i, j int) bool {
+		if res.Nights[i].Date != res.Nights[j].Date {
+			return res.Nights[i].Date < res.Nights[j].Date
+		}
+		return res.Nights[i].RoomNumber < res.Nights[j].RoomNumber
+	})
+	res.BlockedNights = len(res.Nights)
+	return res, nil
+}
+
+//check block dates and that the room is neither blocked nor sold on any night of the block
+func (service *hotelMgmtService) validateRoomBlock(block *models.RoomBlock) error {
+	if _, _, err := dateRange("from_date", block.FromDate, "until_date", block.UntilDate); err != nil {
+		return err
+	}
+	room, err := service.repository.FindRoomByID(block.RoomID)
+	if err != nil {
+		return err
+	}
+
+	blocks, err := service.repository.FindRoomBlocks(room.HotelID, block.FromDate, block.UntilDate)
+	if err != nil {
+		return err
+	}
+	for _, other := range blocks {
+		if other.RoomID == block.RoomID && other.ID != block.ID {
+			return fmt.Errorf("%w: block %d from %s until %s", ErrRoomBlockOverlap, other.ID, other.FromDate[0:10], other.UntilDate[0:10])
+		}
+	}
+
+	nights, err := service.repository.FindStayRooms(room.HotelID, block.FromDate, block.UntilDate)
+	if err != nil {
+		return err
+	}
+	conflict := &RoomBlockConflictError{}
+	for _, night := range nights {
+		if night.RoomID == block.RoomID {
+			conflict.Conflicts = append(conflict.Conflicts, &models.RoomNight{RoomID: night.RoomID, Date: night.Date[0:10]})
+		}
+	}
+	if len(conflict.Conflicts) > 0 {
+		return conflict
+	}
+	return nil
+}
+
+//default report dates from today in hotel timezone until further notice
+func (service *hotelMgmtService) reportDates(hotelID int, fromDate string, toDate string) (string, string, error) {
+	if fromDate == "" {
+		now, err := service.hotelTime(hotelID)
+		if err != nil {
+			return "", "", err
+		}
+		fromDate = now.Format(dateForm)
+	}
+	if toDate == "" {
+		toDate = openEndedDate
+	}
+	if _, _, err := dateRange("from_date", fromDate, "to_date", toDate); err != nil {
+		return "", "", err
+	}
+	return fromDate, toDate, nil
+}
+
+//id of rooms of a hotel blocked on any night between checkin date and checkout date
+func (service *hotelMgmtService) blockedRoomIDs(hotelID int, checkinDate string, checkoutDate string) ([]int, error) {
+	blocks, err := service.repository.FindRoomBlocks(hotelID, checkinDate, checkoutDate)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(blocks))
+	for _, block := range blocks {
+		ids = append(ids, block.RoomID)
+	}
+	return ids, nil
+}
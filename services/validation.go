@@ -0,0 +1,124 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nurcholisnanda/hotel-management-system/models"
+)
+
+//ErrValidation is returned when request fields break validation rules
+var ErrValidation = errors.New("validation failed")
+
+//validation rules reported with the field that broke them
+const (
+	RuleRequired       = "required"
+	RuleFormat         = "format"
+	RuleMin            = "min"
+	RuleMax            = "max"
+	RuleAfter          = "after"
+	RuleNotPast        = "not_past"
+	RuleMinStay        = "min_stay"
+	RuleMaxStay        = "max_stay"
+	RuleBookingHorizon = "booking_horizon"
+	RuleBooked         = "booked"
+	RuleUnique         = "unique"
+)
+
+//ValidationError lists every field that broke a validation rule, it matches ErrValidation
+type ValidationError struct {
+	Fields []*models.FieldError
+}
+
+func (err *ValidationError) Error() string {
+	messages := make([]string, 0, len(err.Fields))
+	for _, field := range err.Fields {
+		messages = append(messages, field.Message)
+	}
+	return fmt.Sprintf("%s: %s", ErrValidation.Error(), strings.Join(messages, "; "))
+}
+
+func (err *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+//Add records a rule broken by a field
+func (err *ValidationError) Add(field string, rule string, message string) {
+	err.Fields = append(err.Fields, &models.FieldError{Field: field, Rule: rule, Message: message})
+}
+
+//Err returns the validation error when a rule is broken, nil otherwise
+func (err *ValidationError) Err() error {
+	if len(err.Fields) == 0 {
+		return nil
+	}
+	return err
+}
+
+//check stay dates and room qty against the stay rules of the hotel, a checkin date already booked may be in the past
+func (service *hotelMgmtService) validateStay(hotelID int, checkinDate string, checkoutDate string, roomQty int, checkinBooked bool) error {
+	invalid := &ValidationError{}
+	checkin, checkinErr := time.Parse(dateForm, checkinDate)
+	if checkinErr != nil {
+		invalid.Add("checkin_date", RuleFormat, fmt.Sprintf("checkin_date must look like %s", dateForm))
+	}
+	checkout, checkoutErr := time.Parse(dateForm, checkoutDate)
+	if checkoutErr != nil {
+		invalid.Add("checkout_date", RuleFormat, fmt.Sprintf("checkout_date must look like %s", dateForm))
+	}
+	if roomQty < 1 {
+		invalid.Add("room_qty", RuleMin, "room_qty must be at least 1")
+	}
+	if checkinErr != nil || checkoutErr != nil {
+		return invalid.Err()
+	}
+	if !checkout.After(checkin) {
+		invalid.Add("checkout_date", RuleAfter, "checkout_date must be after checkin_date")
+		return invalid.Err()
+	}
+
+	hotel, err := service.repository.FindHotelByID(hotelID)
+	if err != nil {
+		return err
+	}
+	now, err := service.hotelTime(hotelID)
+	if err != nil {
+		return err
+	}
+	today, _ := time.Parse(dateForm, now.Format(dateForm))
+	if !checkinBooked {
+		if checkin.Before(today) {
+			invalid.Add("checkin_date", RuleNotPast, fmt.Sprintf("checkin_date can't be before %s, today in hotel timezone", today.Format(dateForm)))
+		}
+		if lastCheckin := today.AddDate(0, 0, hotel.BookingHorizon); hotel.BookingHorizon > 0 && checkin.After(lastCheckin) {
+			invalid.Add("checkin_date", RuleBookingHorizon, fmt.Sprintf("checkin_date can't be after %s, the hotel takes bookings %d days ahead", lastCheckin.Format(dateForm), hotel.BookingHorizon))
+		}
+	}
+	nights := int(checkout.Sub(checkin).Hours() / 24)
+	if hotel.MinStayNights > 0 && nights < hotel.MinStayNights {
+		invalid.Add("checkout_date", RuleMinStay, fmt.Sprintf("stay must be at least %d nights", hotel.MinStayNights))
+	}
+	if hotel.MaxStayNights > 0 && nights > hotel.MaxStayNights {
+		invalid.Add("checkout_date", RuleMaxStay, fmt.Sprintf("stay can't be longer than %d nights", hotel.MaxStayNights))
+	}
+	return invalid.Err()
+}
+
+//parse the first and last date of a range, the last date must be after the first one
+func dateRange(fromField string, fromDate string, toField string, toDate string) (from time.Time, to time.Time, err error) {
+	invalid := &ValidationError{}
+	from, fromErr := time.Parse(dateForm, fromDate)
+	if fromErr != nil {
+		invalid.Add(fromField, RuleFormat, fmt.Sprintf("%s must look like %s", fromField, dateForm))
+	}
+	to, toErr := time.Parse(dateForm, toDate)
+	if toErr != nil {
+		invalid.Add(toField, RuleFormat, fmt.Sprintf("%s must look like %s", toField, dateForm))
+	}
+	if fromErr == nil && toErr == nil && !to.After(from) {
+		invalid.Add(toField, RuleAfter, fmt.Sprintf("%s must be after %s", toField, fromField))
+	}
+	return from, to, invalid.Err()
+}
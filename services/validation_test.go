@@ -0,0 +1,56 @@
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateStay(t *testing.T) {
+	tests := []struct {
+		name          string
+		checkin       string
+		checkout      string
+		roomQty       int
+		checkinBooked bool
+		wantFields    map[string]string
+	}{
+		{"valid stay", "2022-12-01", "2022-12-03", 1, false, nil},
+		{"bad formats", "01/12/2022", "tomorrow", 0, false, map[string]string{"checkin_date": RuleFormat, "checkout_date": RuleFormat, "room_qty": RuleMin}},
+		{"checkout before checkin", "2022-12-03", "2022-12-01", 1, false, map[string]string{"checkout_date": RuleAfter}},
+		{"same day checkout", "2022-12-01", "2022-12-01", 1, false, map[string]string{"checkout_date": RuleAfter}},
+		{"checkin in the past", "2022-11-30", "2022-12-02", 1, false, map[string]string{"checkin_date": RuleNotPast}},
+		{"booked checkin in the past", "2022-11-30", "2022-12-02", 1, true, nil},
+		{"too short", "2022-12-01", "2022-12-02", 1, false, map[string]string{"checkout_date": RuleMinStay}},
+		{"too long", "2022-12-01", "2022-12-16", 1, false, map[string]string{"checkout_date": RuleMaxStay}},
+		{"beyond booking horizon", "2023-03-02", "2023-03-04", 1, false, map[string]string{"checkin_date": RuleBookingHorizon}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepo(1, "2022-12-01", 0, 0)
+			repo.hotel.MinStayNights = 2
+			repo.hotel.MaxStayNights = 14
+			repo.hotel.BookingHorizon = 90
+			service := NewHotelMgmtServiceWithClock(repo, fixedClock(at("2022-12-01 10:00"))).(*hotelMgmtService)
+
+			err := service.validateStay(1, tt.checkin, tt.checkout, tt.roomQty, tt.checkinBooked)
+			if tt.wantFields == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var invalid *ValidationError
+			if !errors.As(err, &invalid) || !errors.Is(err, ErrValidation) {
+				t.Fatalf("expected validation error, got %v", err)
+			}
+			if len(invalid.Fields) != len(tt.wantFields) {
+				t.Fatalf("expected %d field errors, got %+v", len(tt.wantFields), invalid.Fields)
+			}
+			for _, field := range invalid.Fields {
+				if tt.wantFields[field.Field] != field.Rule {
+					t.Fatalf("unexpected field error %+v", field)
+				}
+			}
+		})
+	}
+}